@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/WK-ING/hlf-qscc-parser/qsccparser"
+)
+
+func main() {
+	// qscc function whose output is read from stdin
+	responseType := flag.String("type", "tx", "qscc response type: tx (GetTransactionByID), block (GetBlockByNumber, GetBlockByHash, GetBlockByTxID) or chaininfo (GetChainInfo)")
+	// consensus type of the orderer that cut the block, for its consenter metadata
+	consensusType := flag.String("consensus", "", "orderer consensus type of -type block: etcdraft or BFT, taken from the block itself for config blocks")
+	// keep decoding past the parts that cannot be decoded, they are listed in the Warnings of the output
+	lenient := flag.Bool("lenient", false, "keep decoding past malformed parts and list them in Warnings")
+	// what the decoder reports is written to stderr, so that stdout only carries the output
+	logLevel := flag.String("log-level", "warn", "level of the decoder logs written to stderr: debug, info, warn or error")
+	// how the output is written
+	bytesEncoding := flag.String("bytes", "base64", "encoding of the bytes fields: base64, hex or utf8 (printable UTF-8 as text, base64 otherwise)")
+	rfc3339 := flag.Bool("rfc3339", false, "write timestamps as RFC 3339 strings instead of seconds and nanos")
+	skipCerts := flag.Bool("skip-certs", false, "keep certificates as they are instead of expanding them")
+	certDetail := flag.Bool("cert-detail", false, "add the PEM, fingerprints, signature algorithm OID, subject and issuer attributes and SANs of each certificate")
+	dedup := flag.Bool("dedup", false, "expand each certificate once into the Identities of the output and refer to it by fingerprint")
+	maxDepth := flag.Int("max-depth", 0, "drop the nodes more than this many nodes deep, 0 keeps all of them")
+	maxValueSize := flag.Int("max-value-size", 0, "cut the bytes fields to this many bytes, 0 keeps them whole")
+	// check the signatures of the creator and the endorsers
+	verify := flag.Bool("verify", false, "verify the signatures of transaction envelopes and endorsements")
+	// CA certificates to validate the identities against
+	mspDir := flag.String("msps", "", "directory with an MSP directory per Mspid, holding cacerts/*.pem, intermediatecerts/*.pem and optionally admincerts/*.pem and a config.yaml with NodeOUs")
+	configBlockFile := flag.String("config-block", "", "file with the hex output of qscc GetBlockByNumber for a config block, whose MSPs the identities are validated against")
+	// signature policy the endorsements of each chaincode action are evaluated against
+	policy := flag.String("policy", "", "endorsement policy to evaluate, e.g. \"AND('Org1MSP.peer', 'Org2MSP.peer')\"")
+	flag.Parse()
+
+	var level slog.Level
+	failOnError(level.UnmarshalText([]byte(*logLevel)))
+
+	encodings := map[string]qsccparser.BytesEncoding{
+		"base64": qsccparser.BytesBase64,
+		"hex":    qsccparser.BytesHex,
+		"utf8":   qsccparser.BytesUTF8,
+	}
+	encoding, ok := encodings[*bytesEncoding]
+	if !ok {
+		failOnError(fmt.Errorf("unknown bytes encoding %q", *bytesEncoding))
+	}
+
+	decoder := &qsccparser.Decoder{
+		Lenient:           *lenient,
+		Logger:            slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})),
+		BytesEncoding:     encoding,
+		RFC3339Timestamps: *rfc3339,
+		SkipCertificates:  *skipCerts,
+		CertificateDetail: *certDetail,
+		DedupIdentities:   *dedup,
+		MaxDepth:          *maxDepth,
+		MaxValueSize:      *maxValueSize,
+		VerifySignatures:  *verify,
+	}
+
+	if *policy != "" {
+		endorsementPolicy, err := qsccparser.ParsePolicy(*policy)
+		failOnError(err)
+		decoder.EndorsementPolicy = endorsementPolicy
+	}
+	if *mspDir != "" {
+		msps, err := qsccparser.ReadMSPs(*mspDir)
+		failOnError(err)
+		decoder.MSPs = msps
+	}
+	if *configBlockFile != "" {
+		configBlockText, err := os.ReadFile(*configBlockFile)
+		failOnError(err)
+		configBlockBytes, err := hex.DecodeString(strings.TrimSpace(string(configBlockText)))
+		failOnError(err)
+		configBlock, err := qsccparser.DecodeBlock(configBlockBytes)
+		failOnError(err)
+		msps, err := configBlock.Data.MSPs()
+		failOnError(err)
+		if decoder.MSPs == nil {
+			decoder.MSPs = map[string]*qsccparser.MSP{}
+		}
+		for mspid, msp := range msps {
+			decoder.MSPs[mspid] = msp
+		}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	text, _ := reader.ReadString('\n')
+
+	respBytes, err := hex.DecodeString(strings.TrimSpace(text))
+	failOnError(err)
+
+	switch *responseType {
+	case "tx":
+		newDecodedTx, err := decoder.DecodeTransaction(respBytes)
+		failOnDecodeError(err)
+
+		//MarshalIndent
+		newDecodedTxJSON, err := json.MarshalIndent(newDecodedTx, "", "\t")
+		if err != nil {
+			fmt.Println("error:", err)
+		}
+		fmt.Println(string(newDecodedTxJSON))
+		failOnError(err)
+	case "block":
+		newDecodedBlock, err := decoder.DecodeBlockWithConsensusType(respBytes, *consensusType)
+		failOnDecodeError(err)
+
+		newDecodedBlockJSON, err := json.MarshalIndent(newDecodedBlock, "", "\t")
+		if err != nil {
+			fmt.Println("error:", err)
+		}
+		fmt.Println(string(newDecodedBlockJSON))
+		failOnError(err)
+	case "chaininfo":
+		newDecodedChainInfo, err := decoder.DecodeChainInfo(respBytes)
+		failOnDecodeError(err)
+
+		newDecodedChainInfoJSON, err := json.MarshalIndent(newDecodedChainInfo, "", "\t")
+		if err != nil {
+			fmt.Println("error:", err)
+		}
+		fmt.Println(string(newDecodedChainInfoJSON))
+		failOnError(err)
+	default:
+		failOnError(fmt.Errorf("unknown response type %q", *responseType))
+	}
+}
+
+// failOnDecodeError is failOnError except for the DecodeErrors of a lenient decoder,
+// which are already listed in the Warnings of the output
+func failOnDecodeError(err error) {
+	var decodeErrors qsccparser.DecodeErrors
+	if errors.As(err, &decodeErrors) {
+		return
+	}
+	failOnError(err)
+}
+
+func failOnError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -1,10 +1,11 @@
-module main
+module github.com/WK-ING/hlf-qscc-parser
 
 go 1.21.1
 
 require (
 	github.com/hyperledger/fabric-protos-go v0.3.1
 	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,501 @@
+package qsccparser
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+)
+
+type ParsedBlock struct {
+	// *common.Block
+	Header     *ParsedBlockHeader        //func (*common.Block).GetHeader() *common.BlockHeader
+	Data       *ParsedBlockData          //func (*common.Block).GetData() *common.BlockData
+	Metadata   *ParsedBlockMetadata      //func (*common.Block).GetMetadata() *common.BlockMetadata
+	Failures   []*ParsedFailure          `json:",omitempty"` //parts a lenient Decoder could not decode
+	Warnings   []string                  `json:",omitempty"` //every failure below, with its full path
+	Identities map[string]*ParsedIdBytes `json:",omitempty"` //the certificates below by fingerprint, from a Decoder that dedups identities
+}
+
+func (db *ParsedBlock) DecodeBlock(decoder *Decoder, data []byte) error {
+	return db.DecodeBlockWithConsensusType(decoder, data, "")
+}
+
+// DecodeBlockWithConsensusType decodes a block whose consenter metadata was written by the given
+// orderer.ConsensusType.Type. An empty consensusType is taken from the block itself when it is a
+// config block, otherwise the consenter metadata is kept as raw bytes.
+func (db *ParsedBlock) DecodeBlockWithConsensusType(decoder *Decoder, data []byte, consensusType string) (err error) {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+	decoder = decoder.withIdentities()
+	defer func() { locateError(data, err) }()
+
+	block := &common.Block{}
+	err = block.XXX_Unmarshal(data)
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&db.Failures, unmarshalError("", block, data, err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedBlockHeader := &ParsedBlockHeader{}
+	err = decodedBlockHeader.DecodeBlockHeader(decoder, block.GetHeader())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Header", err)
+	}
+	db.Header = decodedBlockHeader
+
+	decodedBlockData := &ParsedBlockData{}
+	err = decodedBlockData.DecodeBlockData(decoder, block.GetData())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Data", err)
+	}
+	db.Data = decodedBlockData
+
+	if consensusType == "" {
+		consensusType = blockConsensusType(block)
+	}
+
+	decodedBlockMetadata := &ParsedBlockMetadata{}
+	err = decodedBlockMetadata.DecodeBlockMetadata(decoder, block.GetMetadata(), consensusType)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Metadata", err)
+	}
+	db.Metadata = decodedBlockMetadata
+	db.Identities = decoder.identitiesOf()
+
+	logger.Debug("DecodedBlock", "value", db)
+
+	err = collectWarnings(db, data, &db.Warnings)
+	decoder.cutDepth(db)
+
+	return err
+}
+
+type ParsedBlockHeader struct {
+	// *common.BlockHeader
+	Number       uint64      //func (*common.BlockHeader).GetNumber() uint64
+	PreviousHash ParsedBytes //func (*common.BlockHeader).GetPreviousHash() []byte
+	DataHash     ParsedBytes //func (*common.BlockHeader).GetDataHash() []byte
+}
+
+func (dbh *ParsedBlockHeader) DecodeBlockHeader(decoder *Decoder, blockHeader *common.BlockHeader) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dbh.Number = blockHeader.GetNumber()
+	dbh.PreviousHash = decoder.bytes(blockHeader.GetPreviousHash())
+	dbh.DataHash = decoder.bytes(blockHeader.GetDataHash())
+
+	logger.Debug("DecodedBlockHeader", "value", dbh)
+
+	return nil
+}
+
+type ParsedBlockData struct {
+	// *common.BlockData
+	Data     []*ParsedTransactionEnvelope //func (*common.BlockData).GetData() [][]byte
+	Failures []*ParsedFailure             `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dbd *ParsedBlockData) DecodeBlockData(decoder *Decoder, blockData *common.BlockData) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedTransactionEnvelopes := []*ParsedTransactionEnvelope{}
+	for i, data := range blockData.GetData() {
+		envelope := &common.Envelope{}
+		err := envelope.XXX_Unmarshal(data)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dbd.Failures, unmarshalError(fmt.Sprintf("Data[%d]", i), envelope, data, err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedTransactionEnvelope := &ParsedTransactionEnvelope{}
+		err = decodedTransactionEnvelope.DecodeTransactionEnvelope(decoder, envelope)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Data[%d]", i), err)
+		}
+		decodedTransactionEnvelopes = append(decodedTransactionEnvelopes, decodedTransactionEnvelope)
+	}
+	dbd.Data = decodedTransactionEnvelopes
+
+	logger.Debug("DecodedBlockData", "value", dbd)
+
+	return nil
+}
+
+// ConsensusType returns the orderer.ConsensusType.Type of a config block, or "" for any other block
+func (dbd *ParsedBlockData) ConsensusType() string {
+	for _, configEnvelope := range dbd.configEnvelopes() {
+		if configEnvelope.Config.ChannelGroup == nil {
+			continue
+		}
+		ordererGroup, ok := configEnvelope.Config.ChannelGroup.Groups["Orderer"]
+		if !ok || ordererGroup == nil {
+			continue
+		}
+		consensusTypeValue, ok := ordererGroup.Values[ConsensusTypeKey]
+		if !ok || consensusTypeValue == nil {
+			continue
+		}
+		if consensusType, ok := consensusTypeValue.Value.(*ParsedConsensusType); ok {
+			return consensusType.Type
+		}
+	}
+	return ""
+}
+
+// blockConsensusType returns the orderer.ConsensusType.Type of a config block like ConsensusType does, but from the
+// block itself, whose decoded data may stop above the ConsensusType at MaxDepth
+func blockConsensusType(block *common.Block) string {
+	for _, data := range block.GetData().GetData() {
+		envelope := &common.Envelope{}
+		payload := &common.Payload{}
+		channelHeader := &common.ChannelHeader{}
+		configEnvelope := &common.ConfigEnvelope{}
+		if envelope.XXX_Unmarshal(data) != nil || payload.XXX_Unmarshal(envelope.GetPayload()) != nil ||
+			channelHeader.XXX_Unmarshal(payload.GetHeader().GetChannelHeader()) != nil ||
+			channelHeader.GetType() != int32(common.HeaderType_CONFIG) || configEnvelope.XXX_Unmarshal(payload.GetData()) != nil {
+			continue
+		}
+		consensusTypeValue := configEnvelope.GetConfig().GetChannelGroup().GetGroups()["Orderer"].GetValues()[ConsensusTypeKey]
+		consensusType := &orderer.ConsensusType{}
+		if consensusTypeValue != nil && consensusType.XXX_Unmarshal(consensusTypeValue.GetValue()) == nil {
+			return consensusType.GetType()
+		}
+	}
+	return ""
+}
+
+// configEnvelopes returns the config envelopes of the block with their config. It skips the nodes a lenient
+// decoder or MaxDepth left nil, so that the accessors of ParsedBlockData do not depend on a complete tree.
+func (dbd *ParsedBlockData) configEnvelopes() []*ParsedConfigEnvelope {
+	if dbd == nil {
+		return nil
+	}
+	configEnvelopes := []*ParsedConfigEnvelope{}
+	for _, envelope := range dbd.Data {
+		if envelope == nil || envelope.Payload == nil {
+			continue
+		}
+		configEnvelope, ok := envelope.Payload.Data.(*ParsedConfigEnvelope)
+		if !ok || configEnvelope == nil || configEnvelope.Config == nil {
+			continue
+		}
+		configEnvelopes = append(configEnvelopes, configEnvelope)
+	}
+	return configEnvelopes
+}
+
+type ParsedBlockMetadata struct {
+	// *common.BlockMetadata, indexed by common.BlockMetadataIndex
+	Signatures         *ParsedSignaturesMetadata //common.BlockMetadataIndex_SIGNATURES
+	LastConfig         *ParsedLastConfig         //common.BlockMetadataIndex_LAST_CONFIG
+	TransactionsFilter []ParsedEnum              //common.BlockMetadataIndex_TRANSACTIONS_FILTER
+	CommitHash         ParsedBytes               //common.BlockMetadataIndex_COMMIT_HASH
+	Failures           []*ParsedFailure          `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dbm *ParsedBlockMetadata) DecodeBlockMetadata(decoder *Decoder, blockMetadata *common.BlockMetadata, consensusType string) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	metadata := blockMetadata.GetMetadata()
+
+	if len(metadata) > int(common.BlockMetadataIndex_SIGNATURES) {
+		signaturesMetadata := &common.Metadata{}
+		err := signaturesMetadata.XXX_Unmarshal(metadata[common.BlockMetadataIndex_SIGNATURES])
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dbm.Failures, unmarshalError("Signatures", signaturesMetadata, metadata[common.BlockMetadataIndex_SIGNATURES], err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedSignaturesMetadata := &ParsedSignaturesMetadata{}
+		err = decodedSignaturesMetadata.DecodeSignaturesMetadata(decoder, signaturesMetadata, consensusType)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Signatures", err)
+		}
+		dbm.Signatures = decodedSignaturesMetadata
+	}
+
+	if len(metadata) > int(common.BlockMetadataIndex_LAST_CONFIG) {
+		lastConfigMetadata := &common.Metadata{}
+		err := lastConfigMetadata.XXX_Unmarshal(metadata[common.BlockMetadataIndex_LAST_CONFIG])
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dbm.Failures, unmarshalError("LastConfig", lastConfigMetadata, metadata[common.BlockMetadataIndex_LAST_CONFIG], err))
+			if err != nil {
+				return err
+			}
+		}
+		lastConfig := &common.LastConfig{}
+		err = lastConfig.XXX_Unmarshal(lastConfigMetadata.GetValue())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dbm.Failures, unmarshalError("LastConfig", lastConfig, lastConfigMetadata.GetValue(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedLastConfig := &ParsedLastConfig{}
+		err = decodedLastConfig.DecodeLastConfig(decoder, lastConfig)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("LastConfig", err)
+		}
+		dbm.LastConfig = decodedLastConfig
+	}
+
+	if len(metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		// one peer.TxValidationCode per transaction, in block order
+		transactionsFilter := []ParsedEnum{}
+		for _, validationCode := range metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] {
+			transactionsFilter = append(transactionsFilter, validationCodeEnum(int32(validationCode)))
+		}
+		dbm.TransactionsFilter = transactionsFilter
+	}
+
+	if len(metadata) > int(common.BlockMetadataIndex_COMMIT_HASH) {
+		commitHashMetadata := &common.Metadata{}
+		err := commitHashMetadata.XXX_Unmarshal(metadata[common.BlockMetadataIndex_COMMIT_HASH])
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dbm.Failures, unmarshalError("CommitHash", commitHashMetadata, metadata[common.BlockMetadataIndex_COMMIT_HASH], err))
+			if err != nil {
+				return err
+			}
+		}
+		dbm.CommitHash = decoder.bytes(commitHashMetadata.GetValue())
+	}
+
+	logger.Debug("DecodedBlockMetadata", "value", dbm)
+
+	return nil
+}
+
+type ParsedSignaturesMetadata struct {
+	// *common.Metadata
+	Value      *ParsedOrdererBlockMetadata //func (*common.Metadata).GetValue() []byte
+	Signatures []*ParsedMetadataSignature  //func (*common.Metadata).GetSignatures() []*common.MetadataSignature
+	Failures   []*ParsedFailure            `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dsm *ParsedSignaturesMetadata) DecodeSignaturesMetadata(decoder *Decoder, signaturesMetadata *common.Metadata, consensusType string) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	ordererBlockMetadata := &common.OrdererBlockMetadata{}
+	err := ordererBlockMetadata.XXX_Unmarshal(signaturesMetadata.GetValue())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dsm.Failures, unmarshalError("Value", ordererBlockMetadata, signaturesMetadata.GetValue(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedOrdererBlockMetadata := &ParsedOrdererBlockMetadata{}
+	err = decodedOrdererBlockMetadata.DecodeOrdererBlockMetadata(decoder, ordererBlockMetadata, consensusType)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Value", err)
+	}
+	dsm.Value = decodedOrdererBlockMetadata
+
+	decodedMetadataSignatures := []*ParsedMetadataSignature{}
+	for i, metadataSignature := range signaturesMetadata.GetSignatures() {
+		decodedMetadataSignature := &ParsedMetadataSignature{}
+		err := decodedMetadataSignature.DecodeMetadataSignature(decoder, metadataSignature)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Signatures[%d]", i), err)
+		}
+		decodedMetadataSignatures = append(decodedMetadataSignatures, decodedMetadataSignature)
+	}
+	dsm.Signatures = decodedMetadataSignatures
+
+	logger.Debug("DecodedSignaturesMetadata", "value", dsm)
+
+	return nil
+}
+
+type ParsedOrdererBlockMetadata struct {
+	// *common.OrdererBlockMetadata
+	LastConfig        *ParsedLastConfig //func (*common.OrdererBlockMetadata).GetLastConfig() *common.LastConfig
+	ConsenterMetadata interface{}       //func (*common.OrdererBlockMetadata).GetConsenterMetadata() []byte, decoded according to the consensus type
+	Failures          []*ParsedFailure  `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dobm *ParsedOrdererBlockMetadata) DecodeOrdererBlockMetadata(decoder *Decoder, ordererBlockMetadata *common.OrdererBlockMetadata, consensusType string) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedLastConfig := &ParsedLastConfig{}
+	err := decodedLastConfig.DecodeLastConfig(decoder, ordererBlockMetadata.GetLastConfig())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("LastConfig", err)
+	}
+	dobm.LastConfig = decodedLastConfig
+
+	decodedConsenterMetadata, err := decodeConsenterMetadata(decoder, consensusType, ordererBlockMetadata.GetConsenterMetadata())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dobm.Failures, prefixPath("ConsenterMetadata", err))
+		if err != nil {
+			return err
+		}
+		// keep the raw bytes, like for the metadata of other consensus types
+		decodedConsenterMetadata = decoder.bytes(ordererBlockMetadata.GetConsenterMetadata())
+	}
+	dobm.ConsenterMetadata = decodedConsenterMetadata
+
+	logger.Debug("DecodedOrdererBlockMetadata", "value", dobm)
+
+	return nil
+}
+
+type ParsedMetadataSignature struct {
+	// *common.MetadataSignature
+	SignatureHeader  *ParsedSignatureHeader  //func (*common.MetadataSignature).GetSignatureHeader() []byte
+	Signature        ParsedBytes             //func (*common.MetadataSignature).GetSignature() []byte
+	IdentifierHeader *ParsedIdentifierHeader //func (*common.MetadataSignature).GetIdentifierHeader() []byte
+	Failures         []*ParsedFailure        `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dms *ParsedMetadataSignature) DecodeMetadataSignature(decoder *Decoder, metadataSignature *common.MetadataSignature) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	// BFT orderers identify themselves with an IdentifierHeader and leave the SignatureHeader empty
+	if len(metadataSignature.GetSignatureHeader()) > 0 {
+		signatureHeader := &common.SignatureHeader{}
+		err := signatureHeader.XXX_Unmarshal(metadataSignature.GetSignatureHeader())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dms.Failures, unmarshalError("SignatureHeader", signatureHeader, metadataSignature.GetSignatureHeader(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedSignatureHeader := &ParsedSignatureHeader{}
+		err = decodedSignatureHeader.DecodeSignatureHeader(decoder, signatureHeader)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("SignatureHeader", err)
+		}
+		dms.SignatureHeader = decodedSignatureHeader
+	}
+
+	dms.Signature = decoder.bytes(metadataSignature.GetSignature())
+
+	if len(metadataSignature.GetIdentifierHeader()) > 0 {
+		identifierHeader := &common.IdentifierHeader{}
+		err := identifierHeader.XXX_Unmarshal(metadataSignature.GetIdentifierHeader())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dms.Failures, unmarshalError("IdentifierHeader", identifierHeader, metadataSignature.GetIdentifierHeader(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedIdentifierHeader := &ParsedIdentifierHeader{}
+		err = decodedIdentifierHeader.DecodeIdentifierHeader(decoder, identifierHeader)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("IdentifierHeader", err)
+		}
+		dms.IdentifierHeader = decodedIdentifierHeader
+	}
+
+	logger.Debug("DecodedMetadataSignature", "value", dms)
+
+	return nil
+}
+
+type ParsedIdentifierHeader struct {
+	// *common.IdentifierHeader
+	Identifier uint32      //func (*common.IdentifierHeader).GetIdentifier() uint32
+	Nonce      ParsedBytes //func (*common.IdentifierHeader).GetNonce() []byte
+}
+
+func (dih *ParsedIdentifierHeader) DecodeIdentifierHeader(decoder *Decoder, identifierHeader *common.IdentifierHeader) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dih.Identifier = identifierHeader.GetIdentifier()
+	dih.Nonce = decoder.bytes(identifierHeader.GetNonce())
+
+	logger.Debug("DecodedIdentifierHeader", "value", dih)
+
+	return nil
+}
+
+type ParsedLastConfig struct {
+	// *common.LastConfig
+	Index uint64 //func (*common.LastConfig).GetIndex() uint64
+}
+
+func (dlc *ParsedLastConfig) DecodeLastConfig(decoder *Decoder, lastConfig *common.LastConfig) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dlc.Index = lastConfig.GetIndex()
+
+	logger.Debug("DecodedLastConfig", "value", dlc)
+
+	return nil
+}
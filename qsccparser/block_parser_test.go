@@ -0,0 +1,158 @@
+package qsccparser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+func TestDecodeBlock(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	user1 := ca.signer(t, "Org1MSP", "user1", "client")
+	user2 := ca.signer(t, "Org1MSP", "user2", "client")
+	envelopes := []*common.Envelope{
+		(&testTransaction{creator: user1, endorsers: []*testSigner{user1}}).envelope(t),
+		(&testTransaction{creator: user2}).envelope(t),
+	}
+	block := testBlock(t, 9, nil, envelopes...)
+
+	for _, decode := range []func([]byte) (*ParsedBlock, error){DecodeBlock, (&Decoder{}).DecodeBlock, (&Decoder{Lenient: true}).DecodeBlock} {
+		decodedBlock, err := decode(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := decodedBlock.Header
+		if header.Number != 9 || string(header.PreviousHash.Bytes) != "previous hash" || string(header.DataHash.Bytes) != "data hash" {
+			t.Errorf("Header = %+v", header)
+		}
+		if len(decodedBlock.Data.Data) != len(envelopes) {
+			t.Fatalf("Data has %d envelopes, want %d", len(decodedBlock.Data.Data), len(envelopes))
+		}
+		// the transactions are decoded like the ones of GetTransactionByID, in block order
+		for i, creator := range []*testSigner{user1, user2} {
+			envelope := decodedBlock.Data.Data[i]
+			channelHeader := envelope.Payload.Header.ChannelHeader
+			if channelHeader.Type.Name != "ENDORSER_TRANSACTION" || channelHeader.TxId != computeTxId(testNonce, creator.identity) {
+				t.Errorf("Data[%d] ChannelHeader = %+v", i, channelHeader)
+			}
+			if _, ok := envelope.Payload.Data.(*ParsedData); !ok {
+				t.Errorf("Data[%d] Payload.Data = %T, want the transaction", i, envelope.Payload.Data)
+			}
+		}
+		if decodedBlock.Warnings != nil || decodedBlock.Failures != nil {
+			t.Errorf("Warnings = %v, Failures = %v", decodedBlock.Warnings, decodedBlock.Failures)
+		}
+	}
+}
+
+func TestDecodeBlockMalformed(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	envelope := testMarshal(t, (&testTransaction{creator: ca.signer(t, "Org1MSP", "user1", "client")}).envelope(t))
+	malformedData := testMarshal(t, &common.Block{Header: &common.BlockHeader{Number: 1}, Data: &common.BlockData{Data: [][]byte{envelope, {0x0a, 0xff}}}})
+
+	tests := []struct {
+		name    string
+		block   []byte
+		path    string
+		message string
+	}{
+		{"malformed envelope", malformedData, "Data.Data[1]", "common.Envelope"},
+		{"malformed block", []byte{0x0a, 0xff}, "", "common.Block"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decodedBlock, err := DecodeBlock(test.block)
+			decodeErr, ok := err.(*DecodeError)
+			if decodedBlock != nil || !ok || decodeErr.Path != test.path || decodeErr.Message != test.message {
+				t.Fatalf("DecodeBlock() = %v, %v, want the DecodeError of %s at %q", decodedBlock, err, test.message, test.path)
+			}
+
+			decodedBlock, err = (&Decoder{Lenient: true}).DecodeBlock(test.block)
+			var decodeErrors DecodeErrors
+			if decodedBlock == nil || !errors.As(err, &decodeErrors) || len(decodeErrors) != 1 || decodeErrors[0].Path != test.path {
+				t.Fatalf("lenient DecodeBlock() = %v, %v, want the result and the DecodeError at %q", decodedBlock, err, test.path)
+			}
+			if len(decodedBlock.Warnings) != 1 {
+				t.Errorf("Warnings = %v, want 1", decodedBlock.Warnings)
+			}
+		})
+	}
+}
+
+func TestDecodeBlockMetadata(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	orderer := ca.signer(t, "OrdererMSP", "orderer0", "orderer")
+	ordererBlockMetadata := testMarshal(t, &common.OrdererBlockMetadata{LastConfig: &common.LastConfig{Index: 7}})
+	signatureHeader := testMarshal(t, &common.SignatureHeader{Creator: orderer.identity, Nonce: testNonce})
+	signatures := testMarshal(t, &common.Metadata{Value: ordererBlockMetadata, Signatures: []*common.MetadataSignature{{SignatureHeader: signatureHeader, Signature: orderer.sign(t, ordererBlockMetadata)}}})
+	// BFT orderers identify themselves by their consenter id instead
+	identifierHeader := testMarshal(t, &common.IdentifierHeader{Identifier: 2, Nonce: testNonce})
+	bftSignatures := testMarshal(t, &common.Metadata{Value: ordererBlockMetadata, Signatures: []*common.MetadataSignature{{IdentifierHeader: identifierHeader, Signature: []byte("signature")}}})
+	lastConfig := testMarshal(t, &common.Metadata{Value: testMarshal(t, &common.LastConfig{Index: 7})})
+	commitHash := testMarshal(t, &common.Metadata{Value: []byte("commit hash")})
+
+	block := testBlock(t, 9, [][]byte{signatures, lastConfig, {0, 11, 200}, {}, commitHash})
+	decodedBlock, err := DecodeBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := decodedBlock.Metadata
+	if metadata.Signatures == nil || metadata.Signatures.Value == nil || metadata.Signatures.Value.LastConfig.Index != 7 {
+		t.Fatalf("Signatures = %+v", metadata.Signatures)
+	}
+	if len(metadata.Signatures.Signatures) != 1 {
+		t.Fatalf("Signatures.Signatures = %v, want 1", metadata.Signatures.Signatures)
+	}
+	signature := metadata.Signatures.Signatures[0]
+	if signature.SignatureHeader == nil || signature.SignatureHeader.Creator.Mspid != "OrdererMSP" || signature.IdentifierHeader != nil || signature.Signature.Length == 0 {
+		t.Errorf("Signatures.Signatures[0] = %+v", signature)
+	}
+	if metadata.LastConfig == nil || metadata.LastConfig.Index != 7 {
+		t.Errorf("LastConfig = %+v, want 7", metadata.LastConfig)
+	}
+	// one validation code per transaction, named even when it is unknown
+	filter := []ParsedEnum{{0, "VALID"}, {11, "MVCC_READ_CONFLICT"}, {200, "UNKNOWN_VALIDATION_CODE_200"}}
+	if !reflect.DeepEqual(metadata.TransactionsFilter, filter) {
+		t.Errorf("TransactionsFilter = %v, want %v", metadata.TransactionsFilter, filter)
+	}
+	if string(metadata.CommitHash.Bytes) != "commit hash" {
+		t.Errorf("CommitHash = %q", metadata.CommitHash.Bytes)
+	}
+
+	t.Run("BFT", func(t *testing.T) {
+		decodedBlock, err := DecodeBlock(testBlock(t, 9, [][]byte{bftSignatures}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature := decodedBlock.Metadata.Signatures.Signatures[0]
+		if signature.SignatureHeader != nil || signature.IdentifierHeader == nil || signature.IdentifierHeader.Identifier != 2 {
+			t.Errorf("Signatures.Signatures[0] = %+v, want the IdentifierHeader alone", signature)
+		}
+	})
+
+	t.Run("short", func(t *testing.T) {
+		// blocks of old peers may not have every metadata entry
+		decodedBlock, err := DecodeBlock(testBlock(t, 9, [][]byte{signatures}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		metadata := decodedBlock.Metadata
+		if metadata.Signatures == nil || metadata.LastConfig != nil || metadata.TransactionsFilter != nil || metadata.CommitHash.Bytes != nil {
+			t.Errorf("Metadata = %+v, want the Signatures alone", metadata)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		block := testBlock(t, 9, [][]byte{signatures, testMarshal(t, &common.Metadata{Value: []byte{0x0a, 0xff}})})
+		_, err := DecodeBlock(block)
+		if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "Metadata.LastConfig" || decodeErr.Message != "common.LastConfig" {
+			t.Errorf("DecodeBlock() = %v, want the DecodeError of the LastConfig", err)
+		}
+		decodedBlock, _ := (&Decoder{Lenient: true}).DecodeBlock(block)
+		if decodedBlock == nil || len(decodedBlock.Metadata.Failures) != 1 || decodedBlock.Metadata.Signatures == nil {
+			t.Errorf("lenient DecodeBlock() = %+v, want the Signatures and the failure of the LastConfig", decodedBlock)
+		}
+	})
+}
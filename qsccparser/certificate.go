@@ -0,0 +1,83 @@
+package qsccparser
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+)
+
+func (d *Decoder) certificateDetail() bool {
+	return d != nil && d.CertificateDetail
+}
+
+// ParsedName is the subject or issuer of a certificate by attribute, like openssl prints it
+type ParsedName struct {
+	// pkix.Name
+	CN           string   `json:",omitempty"` //func (pkix.Name).CommonName() string
+	SerialNumber string   `json:",omitempty"` //func (pkix.Name).SerialNumber() string
+	O            []string `json:",omitempty"` //func (pkix.Name).Organization() []string
+	OU           []string `json:",omitempty"` //func (pkix.Name).OrganizationalUnit() []string
+	L            []string `json:",omitempty"` //func (pkix.Name).Locality() []string
+	ST           []string `json:",omitempty"` //func (pkix.Name).Province() []string
+	C            []string `json:",omitempty"` //func (pkix.Name).Country() []string
+}
+
+func newParsedName(name pkix.Name) *ParsedName {
+	return &ParsedName{
+		CN:           name.CommonName,
+		SerialNumber: name.SerialNumber,
+		O:            name.Organization,
+		OU:           name.OrganizationalUnit,
+		L:            name.Locality,
+		ST:           name.Province,
+		C:            name.Country,
+	}
+}
+
+// certificatePEM is the certificate as a CERTIFICATE PEM block, for openssl
+func certificatePEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// certificateFingerprints are the hex of the SHA-1 and SHA-256 of the DER of the certificate
+func certificateFingerprints(cert *x509.Certificate) (string, string) {
+	sha1Fingerprint := sha1.Sum(cert.Raw)
+	sha256Fingerprint := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sha1Fingerprint[:]), hex.EncodeToString(sha256Fingerprint[:])
+}
+
+// signatureAlgorithmOID is the OID of the signature algorithm of the certificate, which x509 only names
+func signatureAlgorithmOID(cert *x509.Certificate) (string, error) {
+	certificate := struct {
+		TBSCertificate     asn1.RawValue
+		SignatureAlgorithm pkix.AlgorithmIdentifier
+		SignatureValue     asn1.BitString
+	}{}
+	_, err := asn1.Unmarshal(cert.Raw, &certificate)
+	if err != nil {
+		return "", err
+	}
+	return certificate.SignatureAlgorithm.Algorithm.String(), nil
+}
+
+// subjectAltNames lists the subject alternative names of the certificate like openssl prints them, e.g. DNS:peer0.org1.example.com
+func subjectAltNames(cert *x509.Certificate) []string {
+	names := []string{}
+	for _, dnsName := range cert.DNSNames {
+		names = append(names, "DNS:"+dnsName)
+	}
+	for _, emailAddress := range cert.EmailAddresses {
+		names = append(names, "email:"+emailAddress)
+	}
+	for _, ipAddress := range cert.IPAddresses {
+		names = append(names, "IP Address:"+ipAddress.String())
+	}
+	for _, uri := range cert.URIs {
+		names = append(names, "URI:"+uri.String())
+	}
+	return names
+}
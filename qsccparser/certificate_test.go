@@ -0,0 +1,117 @@
+package qsccparser
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeCertificateDetail(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	key := newTestKey(t)
+	uri, err := url.Parse("spiffe://org1.example.com/peer0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := createTestCertificate(t, &x509.Certificate{
+		SerialNumber: newTestSerialNumber(),
+		Subject: pkix.Name{
+			CommonName:         "peer0.org1.example.com",
+			Organization:       []string{"org1.example.com"},
+			OrganizationalUnit: []string{"peer", "department1"},
+			Locality:           []string{"San Francisco"},
+			Province:           []string{"California"},
+			Country:            []string{"US"},
+		},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		DNSNames:       []string{"peer0.org1.example.com", "peer0"},
+		EmailAddresses: []string{"admin@org1.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{uri},
+	}, ca.cert, &key.PublicKey, ca.key)
+
+	t.Run("with detail", func(t *testing.T) {
+		decodedIdBytes := &ParsedIdBytes{}
+		err := decodedIdBytes.DecodeIdBytes(&Decoder{CertificateDetail: true}, cert.Raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the PEM is the original certificate, for openssl
+		block, _ := pem.Decode([]byte(decodedIdBytes.PEM))
+		if block == nil || block.Type != "CERTIFICATE" {
+			t.Fatalf("PEM = %q, want a CERTIFICATE", decodedIdBytes.PEM)
+		}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || !parsed.Equal(cert) {
+			t.Errorf("the certificate of the PEM = %v, %v, want the original one", parsed, err)
+		}
+
+		sha1Fingerprint := sha1.Sum(cert.Raw)
+		sha256Fingerprint := sha256.Sum256(cert.Raw)
+		if decodedIdBytes.SHA1Fingerprint != hex.EncodeToString(sha1Fingerprint[:]) || decodedIdBytes.SHA256Fingerprint != hex.EncodeToString(sha256Fingerprint[:]) {
+			t.Errorf("SHA1Fingerprint = %s, SHA256Fingerprint = %s, want %x, %x", decodedIdBytes.SHA1Fingerprint, decodedIdBytes.SHA256Fingerprint, sha1Fingerprint, sha256Fingerprint)
+		}
+		// ecdsa-with-SHA256, the SignatureAlgorithm of a P-256 CA
+		if decodedIdBytes.SignatureAlgorithm != "ECDSA-SHA256" || decodedIdBytes.SignatureAlgorithmOID != "1.2.840.10045.4.3.2" {
+			t.Errorf("SignatureAlgorithm = %s, SignatureAlgorithmOID = %s", decodedIdBytes.SignatureAlgorithm, decodedIdBytes.SignatureAlgorithmOID)
+		}
+
+		subjectName := &ParsedName{
+			CN: "peer0.org1.example.com",
+			O:  []string{"org1.example.com"},
+			OU: []string{"peer", "department1"},
+			L:  []string{"San Francisco"},
+			ST: []string{"California"},
+			C:  []string{"US"},
+		}
+		if !reflect.DeepEqual(decodedIdBytes.SubjectName, subjectName) {
+			t.Errorf("SubjectName = %+v, want %+v", decodedIdBytes.SubjectName, subjectName)
+		}
+		if decodedIdBytes.IssuerName == nil || decodedIdBytes.IssuerName.CN != ca.cert.Subject.CommonName || decodedIdBytes.IssuerName.OU != nil {
+			t.Errorf("IssuerName = %+v, want the name of the CA", decodedIdBytes.IssuerName)
+		}
+
+		subjectAltNames := []string{"DNS:peer0.org1.example.com", "DNS:peer0", "email:admin@org1.example.com", "IP Address:10.0.0.1", "URI:spiffe://org1.example.com/peer0"}
+		if !reflect.DeepEqual(decodedIdBytes.SubjectAltNames, subjectAltNames) {
+			t.Errorf("SubjectAltNames = %q, want %q", decodedIdBytes.SubjectAltNames, subjectAltNames)
+		}
+	})
+
+	t.Run("without detail", func(t *testing.T) {
+		// the output stays as small as it was
+		decodedIdBytes := &ParsedIdBytes{}
+		err := decodedIdBytes.DecodeIdBytes(&Decoder{}, cert.Raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decodedIdBytes.PEM != "" || decodedIdBytes.SHA1Fingerprint != "" || decodedIdBytes.SHA256Fingerprint != "" || decodedIdBytes.SignatureAlgorithmOID != "" ||
+			decodedIdBytes.SubjectName != nil || decodedIdBytes.IssuerName != nil || decodedIdBytes.SubjectAltNames != nil {
+			t.Errorf("ParsedIdBytes = %+v, want no detail", decodedIdBytes)
+		}
+		if decodedIdBytes.Subject != cert.Subject.String() {
+			t.Errorf("Subject = %q, want %q", decodedIdBytes.Subject, cert.Subject.String())
+		}
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		creator := ca.signer(t, "Org1MSP", "user1", "client")
+		processedTransaction, err := (&Decoder{CertificateDetail: true}).DecodeTransaction((&testTransaction{creator: creator}).marshal(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		idBytes := processedTransaction.TransactionEnvelope.Payload.Header.SignatureHeader.Creator.IdBytes
+		if idBytes.PEM == "" || idBytes.SubjectName == nil || idBytes.SubjectName.CN != "user1" || !reflect.DeepEqual(idBytes.SubjectName.OU, []string{"client"}) {
+			t.Errorf("Creator.IdBytes = %+v, want the certificate detail", idBytes)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package qsccparser
+
+import (
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+type ParsedBlockchainInfo struct {
+	// *common.BlockchainInfo
+	Height                    uint64                           //func (*common.BlockchainInfo).GetHeight() uint64
+	CurrentBlockHash          ParsedBytes                      //func (*common.BlockchainInfo).GetCurrentBlockHash() []byte
+	PreviousBlockHash         ParsedBytes                      //func (*common.BlockchainInfo).GetPreviousBlockHash() []byte
+	BootstrappingSnapshotInfo *ParsedBootstrappingSnapshotInfo //func (*common.BlockchainInfo).GetBootstrappingSnapshotInfo() *common.BootstrappingSnapshotInfo
+	Failures                  []*ParsedFailure                 `json:",omitempty"` //parts a lenient Decoder could not decode
+	Warnings                  []string                         `json:",omitempty"` //every failure below, with its full path
+}
+
+func (dbi *ParsedBlockchainInfo) DecodeBlockchainInfo(decoder *Decoder, data []byte) (err error) {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+	defer func() { locateError(data, err) }()
+
+	blockchainInfo := &common.BlockchainInfo{}
+	err = blockchainInfo.XXX_Unmarshal(data)
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dbi.Failures, unmarshalError("", blockchainInfo, data, err))
+		if err != nil {
+			return err
+		}
+	}
+
+	dbi.Height = blockchainInfo.GetHeight()
+	dbi.CurrentBlockHash = decoder.bytes(blockchainInfo.GetCurrentBlockHash())
+	dbi.PreviousBlockHash = decoder.bytes(blockchainInfo.GetPreviousBlockHash())
+
+	// only set when the peer joined the channel from a snapshot
+	if blockchainInfo.GetBootstrappingSnapshotInfo() != nil {
+		decodedBootstrappingSnapshotInfo := &ParsedBootstrappingSnapshotInfo{}
+		err := decodedBootstrappingSnapshotInfo.DecodeBootstrappingSnapshotInfo(decoder, blockchainInfo.GetBootstrappingSnapshotInfo())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("BootstrappingSnapshotInfo", err)
+		}
+		dbi.BootstrappingSnapshotInfo = decodedBootstrappingSnapshotInfo
+	}
+
+	logger.Debug("DecodedBlockchainInfo", "value", dbi)
+
+	err = collectWarnings(dbi, data, &dbi.Warnings)
+	decoder.cutDepth(dbi)
+
+	return err
+}
+
+type ParsedBootstrappingSnapshotInfo struct {
+	// *common.BootstrappingSnapshotInfo
+	LastBlockInSnapshot uint64 //func (*common.BootstrappingSnapshotInfo).GetLastBlockInSnapshot() uint64
+}
+
+func (dbsi *ParsedBootstrappingSnapshotInfo) DecodeBootstrappingSnapshotInfo(decoder *Decoder, bootstrappingSnapshotInfo *common.BootstrappingSnapshotInfo) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dbsi.LastBlockInSnapshot = bootstrappingSnapshotInfo.GetLastBlockInSnapshot()
+
+	logger.Debug("DecodedBootstrappingSnapshotInfo", "value", dbsi)
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package qsccparser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+func TestDecodeChainInfo(t *testing.T) {
+	tests := []struct {
+		name           string
+		blockchainInfo *common.BlockchainInfo
+		json           string
+	}{
+		{"from the genesis block", &common.BlockchainInfo{Height: 10, CurrentBlockHash: []byte{1, 2}, PreviousBlockHash: []byte{3, 4}},
+			`{"Height":10,"CurrentBlockHash":"AQI=","PreviousBlockHash":"AwQ=","BootstrappingSnapshotInfo":null}`},
+		{"from a snapshot", &common.BlockchainInfo{Height: 10, CurrentBlockHash: []byte{1, 2}, PreviousBlockHash: []byte{3, 4}, BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{LastBlockInSnapshot: 5}},
+			`{"Height":10,"CurrentBlockHash":"AQI=","PreviousBlockHash":"AwQ=","BootstrappingSnapshotInfo":{"LastBlockInSnapshot":5}}`},
+		{"empty channel", &common.BlockchainInfo{}, `{"Height":0,"CurrentBlockHash":null,"PreviousBlockHash":null,"BootstrappingSnapshotInfo":null}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blockchainInfo, err := DecodeChainInfo(testMarshal(t, test.blockchainInfo))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(blockchainInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, test.json)
+			}
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		blockchainInfo, err := DecodeChainInfo([]byte{0x0a, 0xff})
+		if decodeErr, ok := err.(*DecodeError); blockchainInfo != nil || !ok || decodeErr.Path != "" || decodeErr.Message != "common.BlockchainInfo" || decodeErr.Offset != 0 {
+			t.Errorf("DecodeChainInfo() = %v, %v, want the DecodeError of the response", blockchainInfo, err)
+		}
+		blockchainInfo, err = (&Decoder{Lenient: true}).DecodeChainInfo([]byte{0x0a, 0xff})
+		if blockchainInfo == nil || err == nil || len(blockchainInfo.Failures) != 1 || len(blockchainInfo.Warnings) != 1 {
+			t.Errorf("lenient DecodeChainInfo() = %+v, %v, want the result with its failure", blockchainInfo, err)
+		}
+	})
+}
@@ -0,0 +1,336 @@
+package qsccparser
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+type ParsedConfigEnvelope struct {
+	// *common.ConfigEnvelope
+	Config     *ParsedConfig              //func (*common.ConfigEnvelope).GetConfig() *common.Config
+	LastUpdate *ParsedTransactionEnvelope //func (*common.ConfigEnvelope).GetLastUpdate() *common.Envelope
+}
+
+func (dce *ParsedConfigEnvelope) DecodeConfigEnvelope(decoder *Decoder, configEnvelope *common.ConfigEnvelope) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedConfig := &ParsedConfig{}
+	err := decodedConfig.DecodeConfig(decoder, configEnvelope.GetConfig())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Config", err)
+	}
+	dce.Config = decodedConfig
+
+	// the genesis block has no last update
+	if configEnvelope.GetLastUpdate() != nil {
+		decodedTransactionEnvelope := &ParsedTransactionEnvelope{}
+		err = decodedTransactionEnvelope.DecodeTransactionEnvelope(decoder, configEnvelope.GetLastUpdate())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("LastUpdate", err)
+		}
+		dce.LastUpdate = decodedTransactionEnvelope
+	}
+
+	logger.Debug("DecodedConfigEnvelope", "value", dce)
+
+	return nil
+}
+
+type ParsedConfig struct {
+	// *common.Config
+	Sequence     uint64             //func (*common.Config).GetSequence() uint64
+	ChannelGroup *ParsedConfigGroup //func (*common.Config).GetChannelGroup() *common.ConfigGroup
+}
+
+func (dc *ParsedConfig) DecodeConfig(decoder *Decoder, config *common.Config) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dc.Sequence = config.GetSequence()
+
+	decodedConfigGroup := &ParsedConfigGroup{}
+	err := decodedConfigGroup.DecodeConfigGroup(decoder, config.GetChannelGroup())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChannelGroup", err)
+	}
+	dc.ChannelGroup = decodedConfigGroup
+
+	logger.Debug("DecodedConfig", "value", dc)
+
+	return nil
+}
+
+type ParsedConfigGroup struct {
+	// *common.ConfigGroup
+	Version   uint64                         //func (*common.ConfigGroup).GetVersion() uint64
+	Groups    map[string]*ParsedConfigGroup  //func (*common.ConfigGroup).GetGroups() map[string]*common.ConfigGroup
+	Values    map[string]*ParsedConfigValue  //func (*common.ConfigGroup).GetValues() map[string]*common.ConfigValue
+	Policies  map[string]*ParsedConfigPolicy //func (*common.ConfigGroup).GetPolicies() map[string]*common.ConfigPolicy
+	ModPolicy string                         //func (*common.ConfigGroup).GetModPolicy() string
+}
+
+func (dcg *ParsedConfigGroup) DecodeConfigGroup(decoder *Decoder, configGroup *common.ConfigGroup) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dcg.Version = configGroup.GetVersion()
+
+	decodedConfigGroups := map[string]*ParsedConfigGroup{}
+	for name, group := range configGroup.GetGroups() {
+		decodedConfigGroup := &ParsedConfigGroup{}
+		err := decodedConfigGroup.DecodeConfigGroup(decoder, group)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Groups[%s]", name), err)
+		}
+		decodedConfigGroups[name] = decodedConfigGroup
+	}
+	dcg.Groups = decodedConfigGroups
+
+	decodedConfigValues := map[string]*ParsedConfigValue{}
+	for name, value := range configGroup.GetValues() {
+		decodedConfigValue := &ParsedConfigValue{}
+		err := decodedConfigValue.DecodeConfigValue(decoder, name, value)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Values[%s]", name), err)
+		}
+		decodedConfigValues[name] = decodedConfigValue
+	}
+	dcg.Values = decodedConfigValues
+
+	decodedConfigPolicies := map[string]*ParsedConfigPolicy{}
+	for name, policy := range configGroup.GetPolicies() {
+		decodedConfigPolicy := &ParsedConfigPolicy{}
+		err := decodedConfigPolicy.DecodeConfigPolicy(decoder, policy)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Policies[%s]", name), err)
+		}
+		decodedConfigPolicies[name] = decodedConfigPolicy
+	}
+	dcg.Policies = decodedConfigPolicies
+
+	dcg.ModPolicy = configGroup.GetModPolicy()
+
+	logger.Debug("DecodedConfigGroup", "value", dcg)
+
+	return nil
+}
+
+type ParsedConfigValue struct {
+	// *common.ConfigValue
+	Version   uint64           //func (*common.ConfigValue).GetVersion() uint64
+	Value     interface{}      //func (*common.ConfigValue).GetValue() []byte, decoded according to the key of the value in its ConfigGroup
+	ModPolicy string           //func (*common.ConfigValue).GetModPolicy() string
+	Failures  []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dcv *ParsedConfigValue) DecodeConfigValue(decoder *Decoder, key string, configValue *common.ConfigValue) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dcv.Version = configValue.GetVersion()
+
+	decodedValue, err := decodeConfigValueByKey(decoder, key, configValue.GetValue())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dcv.Failures, prefixPath("Value", err))
+		if err != nil {
+			return err
+		}
+		// keep the raw bytes, like for the values of unknown keys
+		decodedValue = decoder.bytes(configValue.GetValue())
+	}
+	dcv.Value = decodedValue
+
+	dcv.ModPolicy = configValue.GetModPolicy()
+
+	logger.Debug("DecodedConfigValue", "value", dcv)
+
+	return nil
+}
+
+type ParsedConfigPolicy struct {
+	// *common.ConfigPolicy
+	Version   uint64        //func (*common.ConfigPolicy).GetVersion() uint64
+	Policy    *ParsedPolicy //func (*common.ConfigPolicy).GetPolicy() *common.Policy
+	ModPolicy string        //func (*common.ConfigPolicy).GetModPolicy() string
+}
+
+func (dcp *ParsedConfigPolicy) DecodeConfigPolicy(decoder *Decoder, configPolicy *common.ConfigPolicy) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dcp.Version = configPolicy.GetVersion()
+
+	decodedPolicy := &ParsedPolicy{}
+	err := decodedPolicy.DecodePolicy(decoder, configPolicy.GetPolicy())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Policy", err)
+	}
+	dcp.Policy = decodedPolicy
+
+	dcp.ModPolicy = configPolicy.GetModPolicy()
+
+	logger.Debug("DecodedConfigPolicy", "value", dcp)
+
+	return nil
+}
+
+type ParsedConfigUpdateEnvelope struct {
+	// *common.ConfigUpdateEnvelope
+	ConfigUpdate *ParsedConfigUpdate      //func (*common.ConfigUpdateEnvelope).GetConfigUpdate() []byte
+	Signatures   []*ParsedConfigSignature //func (*common.ConfigUpdateEnvelope).GetSignatures() []*common.ConfigSignature
+	Failures     []*ParsedFailure         `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dcue *ParsedConfigUpdateEnvelope) DecodeConfigUpdateEnvelope(decoder *Decoder, configUpdateEnvelope *common.ConfigUpdateEnvelope) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	configUpdate := &common.ConfigUpdate{}
+	err := configUpdate.XXX_Unmarshal(configUpdateEnvelope.GetConfigUpdate())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dcue.Failures, unmarshalError("ConfigUpdate", configUpdate, configUpdateEnvelope.GetConfigUpdate(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedConfigUpdate := &ParsedConfigUpdate{}
+	err = decodedConfigUpdate.DecodeConfigUpdate(decoder, configUpdate)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ConfigUpdate", err)
+	}
+	dcue.ConfigUpdate = decodedConfigUpdate
+
+	decodedConfigSignatures := []*ParsedConfigSignature{}
+	for i, configSignature := range configUpdateEnvelope.GetSignatures() {
+		decodedConfigSignature := &ParsedConfigSignature{}
+		err := decodedConfigSignature.DecodeConfigSignature(decoder, configSignature)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Signatures[%d]", i), err)
+		}
+		decodedConfigSignatures = append(decodedConfigSignatures, decodedConfigSignature)
+	}
+	dcue.Signatures = decodedConfigSignatures
+
+	logger.Debug("DecodedConfigUpdateEnvelope", "value", dcue)
+
+	return nil
+}
+
+type ParsedConfigUpdate struct {
+	// *common.ConfigUpdate
+	ChannelId    string                 //func (*common.ConfigUpdate).GetChannelId() string
+	ReadSet      *ParsedConfigGroup     //func (*common.ConfigUpdate).GetReadSet() *common.ConfigGroup
+	WriteSet     *ParsedConfigGroup     //func (*common.ConfigUpdate).GetWriteSet() *common.ConfigGroup
+	IsolatedData map[string]ParsedBytes //func (*common.ConfigUpdate).GetIsolatedData() map[string][]byte
+}
+
+func (dcu *ParsedConfigUpdate) DecodeConfigUpdate(decoder *Decoder, configUpdate *common.ConfigUpdate) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dcu.ChannelId = configUpdate.GetChannelId()
+
+	decodedReadSet := &ParsedConfigGroup{}
+	err := decodedReadSet.DecodeConfigGroup(decoder, configUpdate.GetReadSet())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ReadSet", err)
+	}
+	dcu.ReadSet = decodedReadSet
+
+	decodedWriteSet := &ParsedConfigGroup{}
+	err = decodedWriteSet.DecodeConfigGroup(decoder, configUpdate.GetWriteSet())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("WriteSet", err)
+	}
+	dcu.WriteSet = decodedWriteSet
+
+	dcu.IsolatedData = decoder.bytesMap(configUpdate.GetIsolatedData())
+
+	logger.Debug("DecodedConfigUpdate", "value", dcu)
+
+	return nil
+}
+
+type ParsedConfigSignature struct {
+	// *common.ConfigSignature
+	SignatureHeader *ParsedSignatureHeader //func (*common.ConfigSignature).GetSignatureHeader() []byte
+	Signature       ParsedBytes            //func (*common.ConfigSignature).GetSignature() []byte
+	Failures        []*ParsedFailure       `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dcs *ParsedConfigSignature) DecodeConfigSignature(decoder *Decoder, configSignature *common.ConfigSignature) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	signatureHeader := &common.SignatureHeader{}
+	err := signatureHeader.XXX_Unmarshal(configSignature.GetSignatureHeader())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dcs.Failures, unmarshalError("SignatureHeader", signatureHeader, configSignature.GetSignatureHeader(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedSignatureHeader := &ParsedSignatureHeader{}
+	err = decodedSignatureHeader.DecodeSignatureHeader(decoder, signatureHeader)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("SignatureHeader", err)
+	}
+	dcs.SignatureHeader = decodedSignatureHeader
+
+	dcs.Signature = decoder.bytes(configSignature.GetSignature())
+
+	logger.Debug("DecodedConfigSignature", "value", dcs)
+
+	return nil
+}
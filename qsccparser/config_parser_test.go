@@ -0,0 +1,126 @@
+package qsccparser
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestDecodeConfigBlock(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	admin := ca.signer(t, "Org1MSP", "admin", "admin")
+	channelGroup := testChannelGroup(t, ca)
+
+	tests := []struct {
+		name       string
+		sequence   uint64
+		lastUpdate *common.Envelope
+	}{
+		// the genesis block has no last update
+		{"genesis", 0, nil},
+		{"updated", 1, testConfigUpdate(t, admin, channelGroup)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configEnvelope := testMarshal(t, &common.ConfigEnvelope{Config: &common.Config{Sequence: test.sequence, ChannelGroup: channelGroup}, LastUpdate: test.lastUpdate})
+			block, err := DecodeBlock(testBlock(t, test.sequence, nil, testEnvelope(t, common.HeaderType_CONFIG, configEnvelope)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			payload := block.Data.Data[0].Payload
+			if payload.Header.ChannelHeader.Type.Name != "CONFIG" {
+				t.Errorf("ChannelHeader.Type = %v", payload.Header.ChannelHeader.Type)
+			}
+			decodedConfigEnvelope, ok := payload.Data.(*ParsedConfigEnvelope)
+			if !ok {
+				t.Fatalf("Payload.Data = %T, want a config envelope rather than a transaction", payload.Data)
+			}
+			config := decodedConfigEnvelope.Config
+			if config.Sequence != test.sequence || len(config.ChannelGroup.Groups) != 2 || len(config.ChannelGroup.Values) != 3 {
+				t.Errorf("Config = %+v", config)
+			}
+			organization := config.ChannelGroup.Groups["Application"].Groups["Org1MSP"]
+			if organization == nil || organization.ModPolicy != "Admins" || len(organization.Values) != 2 || organization.Policies["Admins"].Policy == nil {
+				t.Errorf("the group of Org1MSP = %+v", organization)
+			}
+			if consensusType := block.Data.ConsensusType(); consensusType != EtcdraftConsensusType {
+				t.Errorf("ConsensusType() = %q, want %q", consensusType, EtcdraftConsensusType)
+			}
+
+			if test.lastUpdate == nil {
+				if decodedConfigEnvelope.LastUpdate != nil {
+					t.Errorf("LastUpdate = %+v, want none", decodedConfigEnvelope.LastUpdate)
+				}
+				return
+			}
+			lastUpdate, ok := decodedConfigEnvelope.LastUpdate.Payload.Data.(*ParsedConfigUpdateEnvelope)
+			if !ok {
+				t.Fatalf("LastUpdate.Payload.Data = %T, want a config update envelope", decodedConfigEnvelope.LastUpdate.Payload.Data)
+			}
+			checkConfigUpdateEnvelope(t, lastUpdate)
+		})
+	}
+}
+
+func TestDecodeConfigUpdateTransaction(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	admin := ca.signer(t, "Org1MSP", "admin", "admin")
+	envelope := testConfigUpdate(t, admin, testChannelGroup(t, ca))
+
+	processedTransaction, err := DecodeTransaction(testMarshal(t, &peer.ProcessedTransaction{TransactionEnvelope: envelope}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configUpdateEnvelope, ok := processedTransaction.TransactionEnvelope.Payload.Data.(*ParsedConfigUpdateEnvelope)
+	if !ok {
+		t.Fatalf("Payload.Data = %T, want a config update envelope", processedTransaction.TransactionEnvelope.Payload.Data)
+	}
+	checkConfigUpdateEnvelope(t, configUpdateEnvelope)
+}
+
+func checkConfigUpdateEnvelope(t *testing.T, configUpdateEnvelope *ParsedConfigUpdateEnvelope) {
+	t.Helper()
+	configUpdate := configUpdateEnvelope.ConfigUpdate
+	if configUpdate.ChannelId != "mychannel" || configUpdate.ReadSet == nil || configUpdate.WriteSet.Groups["Application"] == nil {
+		t.Errorf("ConfigUpdate = %+v", configUpdate)
+	}
+	if len(configUpdateEnvelope.Signatures) != 1 {
+		t.Fatalf("Signatures = %v, want 1", configUpdateEnvelope.Signatures)
+	}
+	signature := configUpdateEnvelope.Signatures[0]
+	if signature.SignatureHeader.Creator.Mspid != "Org1MSP" || signature.SignatureHeader.Creator.IdBytes == nil || signature.Signature.Length == 0 {
+		t.Errorf("Signatures[0] = %+v", signature)
+	}
+}
+
+func TestDecodeConfigMalformed(t *testing.T) {
+	malformed := []byte{0x0a, 0xff}
+	configUpdateEnvelope := testMarshal(t, &common.ConfigUpdateEnvelope{ConfigUpdate: malformed})
+
+	tests := []struct {
+		name       string
+		headerType common.HeaderType
+		data       []byte
+		path       string
+		message    string
+	}{
+		{"config envelope", common.HeaderType_CONFIG, malformed, "Data.Data[0].Payload.Data", "common.ConfigEnvelope"},
+		{"config update envelope", common.HeaderType_CONFIG_UPDATE, malformed, "Data.Data[0].Payload.Data", "common.ConfigUpdateEnvelope"},
+		{"config update", common.HeaderType_CONFIG_UPDATE, configUpdateEnvelope, "Data.Data[0].Payload.Data.ConfigUpdate", "common.ConfigUpdate"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			block := testBlock(t, 1, nil, testEnvelope(t, test.headerType, test.data))
+			_, err := DecodeBlock(block)
+			if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != test.path || decodeErr.Message != test.message {
+				t.Errorf("DecodeBlock() = %v, want the DecodeError of %s at %s", err, test.message, test.path)
+			}
+			decodedBlock, _ := (&Decoder{Lenient: true}).DecodeBlock(block)
+			if decodedBlock == nil || len(decodedBlock.Warnings) != 1 {
+				t.Errorf("lenient DecodeBlock() = %+v, want the block with 1 warning", decodedBlock)
+			}
+		})
+	}
+}
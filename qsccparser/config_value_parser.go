@@ -0,0 +1,765 @@
+package qsccparser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// well-known ConfigGroup value keys, see fabric/common/channelconfig
+const (
+	MSPKey                       = "MSP"
+	BatchSizeKey                 = "BatchSize"
+	BatchTimeoutKey              = "BatchTimeout"
+	ConsensusTypeKey             = "ConsensusType"
+	OrdererAddressesKey          = "OrdererAddresses"
+	EndpointsKey                 = "Endpoints"
+	AnchorPeersKey               = "AnchorPeers"
+	CapabilitiesKey              = "Capabilities"
+	ACLsKey                      = "ACLs"
+	HashingAlgorithmKey          = "HashingAlgorithm"
+	BlockDataHashingStructureKey = "BlockDataHashingStructure"
+	OrderersKey                  = "Orderers"
+)
+
+// msp.MSPConfig.Type values, see fabric/msp.ProviderType
+var mspConfigTypeName = map[int32]string{
+	0: "FABRIC",
+	1: "IDEMIX",
+}
+
+// decodeConfigValueByKey decodes the value of a ConfigValue according to the key it is stored under.
+// Values under keys it does not know are returned as raw bytes.
+func decodeConfigValueByKey(decoder *Decoder, key string, value []byte) (interface{}, error) {
+	logger := decoder.logger()
+
+	switch key {
+	case MSPKey:
+		mspConfig := &msp.MSPConfig{}
+		err := mspConfig.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", mspConfig, value, err)
+		}
+
+		decodedMSPConfig := &ParsedMSPConfig{}
+		err = decodedMSPConfig.DecodeMSPConfig(decoder, mspConfig)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedMSPConfig, nil
+	case BatchSizeKey:
+		batchSize := &orderer.BatchSize{}
+		err := batchSize.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", batchSize, value, err)
+		}
+
+		decodedBatchSize := &ParsedBatchSize{}
+		err = decodedBatchSize.DecodeBatchSize(decoder, batchSize)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedBatchSize, nil
+	case BatchTimeoutKey:
+		batchTimeout := &orderer.BatchTimeout{}
+		err := batchTimeout.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", batchTimeout, value, err)
+		}
+
+		decodedBatchTimeout := &ParsedBatchTimeout{}
+		err = decodedBatchTimeout.DecodeBatchTimeout(decoder, batchTimeout)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedBatchTimeout, nil
+	case ConsensusTypeKey:
+		consensusType := &orderer.ConsensusType{}
+		err := consensusType.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", consensusType, value, err)
+		}
+
+		decodedConsensusType := &ParsedConsensusType{}
+		err = decodedConsensusType.DecodeConsensusType(decoder, consensusType)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedConsensusType, nil
+	case OrdererAddressesKey, EndpointsKey:
+		ordererAddresses := &common.OrdererAddresses{}
+		err := ordererAddresses.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", ordererAddresses, value, err)
+		}
+
+		decodedOrdererAddresses := &ParsedOrdererAddresses{}
+		err = decodedOrdererAddresses.DecodeOrdererAddresses(decoder, ordererAddresses)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedOrdererAddresses, nil
+	case AnchorPeersKey:
+		anchorPeers := &peer.AnchorPeers{}
+		err := anchorPeers.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", anchorPeers, value, err)
+		}
+
+		decodedAnchorPeers := &ParsedAnchorPeers{}
+		err = decodedAnchorPeers.DecodeAnchorPeers(decoder, anchorPeers)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedAnchorPeers, nil
+	case CapabilitiesKey:
+		capabilities := &common.Capabilities{}
+		err := capabilities.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", capabilities, value, err)
+		}
+
+		decodedCapabilities := &ParsedCapabilities{}
+		err = decodedCapabilities.DecodeCapabilities(decoder, capabilities)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedCapabilities, nil
+	case ACLsKey:
+		acls := &peer.ACLs{}
+		err := acls.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", acls, value, err)
+		}
+
+		decodedACLs := &ParsedACLs{}
+		err = decodedACLs.DecodeACLs(decoder, acls)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedACLs, nil
+	case HashingAlgorithmKey:
+		hashingAlgorithm := &common.HashingAlgorithm{}
+		err := hashingAlgorithm.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", hashingAlgorithm, value, err)
+		}
+
+		decodedHashingAlgorithm := &ParsedHashingAlgorithm{}
+		err = decodedHashingAlgorithm.DecodeHashingAlgorithm(decoder, hashingAlgorithm)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedHashingAlgorithm, nil
+	case BlockDataHashingStructureKey:
+		blockDataHashingStructure := &common.BlockDataHashingStructure{}
+		err := blockDataHashingStructure.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", blockDataHashingStructure, value, err)
+		}
+
+		decodedBlockDataHashingStructure := &ParsedBlockDataHashingStructure{}
+		err = decodedBlockDataHashingStructure.DecodeBlockDataHashingStructure(decoder, blockDataHashingStructure)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedBlockDataHashingStructure, nil
+	case OrderersKey:
+		orderers := &common.Orderers{}
+		err := orderers.XXX_Unmarshal(value)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", orderers, value, err)
+		}
+
+		decodedOrderers := &ParsedOrderers{}
+		err = decodedOrderers.DecodeOrderers(decoder, orderers)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedOrderers, nil
+	}
+
+	return decoder.bytes(value), nil
+}
+
+// decodeCertificates runs each PEM certificate through ParsedIdBytes
+func decodeCertificates(decoder *Decoder, certificates [][]byte) ([]*ParsedIdBytes, error) {
+	decodedCertificates := []*ParsedIdBytes{}
+	for i, certificate := range certificates {
+		decodedIdBytes := &ParsedIdBytes{}
+		err := decodedIdBytes.DecodeIdBytes(decoder, certificate)
+		if err != nil {
+			return nil, prefixPath(fmt.Sprintf("[%d]", i), err)
+		}
+		decodedCertificates = append(decodedCertificates, decodedIdBytes)
+	}
+	return decodedCertificates, nil
+}
+
+type ParsedMSPConfig struct {
+	// *msp.MSPConfig
+	Type     string           //func (*msp.MSPConfig).GetType() int32
+	Config   interface{}      //func (*msp.MSPConfig).GetConfig() []byte, decoded according to Type
+	Failures []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dmc *ParsedMSPConfig) DecodeMSPConfig(decoder *Decoder, mspConfig *msp.MSPConfig) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dmc.Type = mspConfigTypeName[mspConfig.GetType()]
+
+	switch dmc.Type {
+	case "FABRIC":
+		fabricMSPConfig := &msp.FabricMSPConfig{}
+		err := fabricMSPConfig.XXX_Unmarshal(mspConfig.GetConfig())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dmc.Failures, unmarshalError("Config", fabricMSPConfig, mspConfig.GetConfig(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedFabricMSPConfig := &ParsedFabricMSPConfig{}
+		err = decodedFabricMSPConfig.DecodeFabricMSPConfig(decoder, fabricMSPConfig)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Config", err)
+		}
+		dmc.Config = decodedFabricMSPConfig
+	case "IDEMIX":
+		idemixMSPConfig := &msp.IdemixMSPConfig{}
+		err := idemixMSPConfig.XXX_Unmarshal(mspConfig.GetConfig())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dmc.Failures, unmarshalError("Config", idemixMSPConfig, mspConfig.GetConfig(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedIdemixMSPConfig := &ParsedIdemixMSPConfig{}
+		err = decodedIdemixMSPConfig.DecodeIdemixMSPConfig(decoder, idemixMSPConfig)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Config", err)
+		}
+		dmc.Config = decodedIdemixMSPConfig
+	default:
+		dmc.Config = decoder.bytes(mspConfig.GetConfig())
+	}
+
+	logger.Debug("DecodedMSPConfig", "value", dmc)
+
+	return nil
+}
+
+type ParsedFabricMSPConfig struct {
+	// *msp.FabricMSPConfig
+	Name                          string                      //func (*msp.FabricMSPConfig).GetName() string
+	RootCerts                     []*ParsedIdBytes            //func (*msp.FabricMSPConfig).GetRootCerts() [][]byte
+	IntermediateCerts             []*ParsedIdBytes            //func (*msp.FabricMSPConfig).GetIntermediateCerts() [][]byte
+	Admins                        []*ParsedIdBytes            //func (*msp.FabricMSPConfig).GetAdmins() [][]byte
+	RevocationList                []ParsedBytes               //func (*msp.FabricMSPConfig).GetRevocationList() [][]byte
+	OrganizationalUnitIdentifiers []*ParsedFabricOUIdentifier //func (*msp.FabricMSPConfig).GetOrganizationalUnitIdentifiers() []*msp.FabricOUIdentifier
+	CryptoConfig                  *ParsedFabricCryptoConfig   //func (*msp.FabricMSPConfig).GetCryptoConfig() *msp.FabricCryptoConfig
+	TlsRootCerts                  []*ParsedIdBytes            //func (*msp.FabricMSPConfig).GetTlsRootCerts() [][]byte
+	TlsIntermediateCerts          []*ParsedIdBytes            //func (*msp.FabricMSPConfig).GetTlsIntermediateCerts() [][]byte
+	FabricNodeOus                 *ParsedFabricNodeOUs        //func (*msp.FabricMSPConfig).GetFabricNodeOus() *msp.FabricNodeOUs
+
+	fabricMSPConfig *msp.FabricMSPConfig // for ParsedBlockData.MSPs
+}
+
+func (dfmc *ParsedFabricMSPConfig) DecodeFabricMSPConfig(decoder *Decoder, fabricMSPConfig *msp.FabricMSPConfig) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dfmc.Name = fabricMSPConfig.GetName()
+	dfmc.fabricMSPConfig = fabricMSPConfig
+
+	var err error
+	dfmc.RootCerts, err = decodeCertificates(decoder, fabricMSPConfig.GetRootCerts())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("RootCerts", err)
+	}
+	dfmc.IntermediateCerts, err = decodeCertificates(decoder, fabricMSPConfig.GetIntermediateCerts())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("IntermediateCerts", err)
+	}
+	dfmc.Admins, err = decodeCertificates(decoder, fabricMSPConfig.GetAdmins())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Admins", err)
+	}
+
+	dfmc.RevocationList = decoder.bytesList(fabricMSPConfig.GetRevocationList())
+
+	decodedFabricOUIdentifiers := []*ParsedFabricOUIdentifier{}
+	for i, fabricOUIdentifier := range fabricMSPConfig.GetOrganizationalUnitIdentifiers() {
+		decodedFabricOUIdentifier := &ParsedFabricOUIdentifier{}
+		err := decodedFabricOUIdentifier.DecodeFabricOUIdentifier(decoder, fabricOUIdentifier)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("OrganizationalUnitIdentifiers[%d]", i), err)
+		}
+		decodedFabricOUIdentifiers = append(decodedFabricOUIdentifiers, decodedFabricOUIdentifier)
+	}
+	dfmc.OrganizationalUnitIdentifiers = decodedFabricOUIdentifiers
+
+	decodedFabricCryptoConfig := &ParsedFabricCryptoConfig{}
+	err = decodedFabricCryptoConfig.DecodeFabricCryptoConfig(decoder, fabricMSPConfig.GetCryptoConfig())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("CryptoConfig", err)
+	}
+	dfmc.CryptoConfig = decodedFabricCryptoConfig
+
+	dfmc.TlsRootCerts, err = decodeCertificates(decoder, fabricMSPConfig.GetTlsRootCerts())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("TlsRootCerts", err)
+	}
+	dfmc.TlsIntermediateCerts, err = decodeCertificates(decoder, fabricMSPConfig.GetTlsIntermediateCerts())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("TlsIntermediateCerts", err)
+	}
+
+	if fabricMSPConfig.GetFabricNodeOus() != nil {
+		decodedFabricNodeOUs := &ParsedFabricNodeOUs{}
+		err = decodedFabricNodeOUs.DecodeFabricNodeOUs(decoder, fabricMSPConfig.GetFabricNodeOus())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("FabricNodeOus", err)
+		}
+		dfmc.FabricNodeOus = decodedFabricNodeOUs
+	}
+
+	logger.Debug("DecodedFabricMSPConfig", "value", dfmc)
+
+	return nil
+}
+
+type ParsedFabricOUIdentifier struct {
+	// *msp.FabricOUIdentifier
+	Certificate                  *ParsedIdBytes //func (*msp.FabricOUIdentifier).GetCertificate() []byte
+	OrganizationalUnitIdentifier string         //func (*msp.FabricOUIdentifier).GetOrganizationalUnitIdentifier() string
+}
+
+func (dfoi *ParsedFabricOUIdentifier) DecodeFabricOUIdentifier(decoder *Decoder, fabricOUIdentifier *msp.FabricOUIdentifier) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	// the certificate is optional, without it the OU matches identities from any CA of the MSP
+	if len(fabricOUIdentifier.GetCertificate()) > 0 {
+		decodedIdBytes := &ParsedIdBytes{}
+		err := decodedIdBytes.DecodeIdBytes(decoder, fabricOUIdentifier.GetCertificate())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Certificate", err)
+		}
+		dfoi.Certificate = decodedIdBytes
+	}
+
+	dfoi.OrganizationalUnitIdentifier = fabricOUIdentifier.GetOrganizationalUnitIdentifier()
+
+	logger.Debug("DecodedFabricOUIdentifier", "value", dfoi)
+
+	return nil
+}
+
+type ParsedFabricCryptoConfig struct {
+	// *msp.FabricCryptoConfig
+	SignatureHashFamily            string //func (*msp.FabricCryptoConfig).GetSignatureHashFamily() string
+	IdentityIdentifierHashFunction string //func (*msp.FabricCryptoConfig).GetIdentityIdentifierHashFunction() string
+}
+
+func (dfcc *ParsedFabricCryptoConfig) DecodeFabricCryptoConfig(decoder *Decoder, fabricCryptoConfig *msp.FabricCryptoConfig) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dfcc.SignatureHashFamily = fabricCryptoConfig.GetSignatureHashFamily()
+	dfcc.IdentityIdentifierHashFunction = fabricCryptoConfig.GetIdentityIdentifierHashFunction()
+
+	logger.Debug("DecodedFabricCryptoConfig", "value", dfcc)
+
+	return nil
+}
+
+type ParsedFabricNodeOUs struct {
+	// *msp.FabricNodeOUs
+	Enable              bool                      //func (*msp.FabricNodeOUs).GetEnable() bool
+	ClientOuIdentifier  *ParsedFabricOUIdentifier //func (*msp.FabricNodeOUs).GetClientOuIdentifier() *msp.FabricOUIdentifier
+	PeerOuIdentifier    *ParsedFabricOUIdentifier //func (*msp.FabricNodeOUs).GetPeerOuIdentifier() *msp.FabricOUIdentifier
+	AdminOuIdentifier   *ParsedFabricOUIdentifier //func (*msp.FabricNodeOUs).GetAdminOuIdentifier() *msp.FabricOUIdentifier
+	OrdererOuIdentifier *ParsedFabricOUIdentifier //func (*msp.FabricNodeOUs).GetOrdererOuIdentifier() *msp.FabricOUIdentifier
+}
+
+func (dfno *ParsedFabricNodeOUs) DecodeFabricNodeOUs(decoder *Decoder, fabricNodeOUs *msp.FabricNodeOUs) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dfno.Enable = fabricNodeOUs.GetEnable()
+
+	if fabricNodeOUs.GetClientOuIdentifier() != nil {
+		decodedClientOuIdentifier := &ParsedFabricOUIdentifier{}
+		err := decodedClientOuIdentifier.DecodeFabricOUIdentifier(decoder, fabricNodeOUs.GetClientOuIdentifier())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("ClientOuIdentifier", err)
+		}
+		dfno.ClientOuIdentifier = decodedClientOuIdentifier
+	}
+
+	if fabricNodeOUs.GetPeerOuIdentifier() != nil {
+		decodedPeerOuIdentifier := &ParsedFabricOUIdentifier{}
+		err := decodedPeerOuIdentifier.DecodeFabricOUIdentifier(decoder, fabricNodeOUs.GetPeerOuIdentifier())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("PeerOuIdentifier", err)
+		}
+		dfno.PeerOuIdentifier = decodedPeerOuIdentifier
+	}
+
+	if fabricNodeOUs.GetAdminOuIdentifier() != nil {
+		decodedAdminOuIdentifier := &ParsedFabricOUIdentifier{}
+		err := decodedAdminOuIdentifier.DecodeFabricOUIdentifier(decoder, fabricNodeOUs.GetAdminOuIdentifier())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("AdminOuIdentifier", err)
+		}
+		dfno.AdminOuIdentifier = decodedAdminOuIdentifier
+	}
+
+	if fabricNodeOUs.GetOrdererOuIdentifier() != nil {
+		decodedOrdererOuIdentifier := &ParsedFabricOUIdentifier{}
+		err := decodedOrdererOuIdentifier.DecodeFabricOUIdentifier(decoder, fabricNodeOUs.GetOrdererOuIdentifier())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("OrdererOuIdentifier", err)
+		}
+		dfno.OrdererOuIdentifier = decodedOrdererOuIdentifier
+	}
+
+	logger.Debug("DecodedFabricNodeOUs", "value", dfno)
+
+	return nil
+}
+
+type ParsedIdemixMSPConfig struct {
+	// *msp.IdemixMSPConfig
+	Name         string      //func (*msp.IdemixMSPConfig).GetName() string
+	Ipk          ParsedBytes //func (*msp.IdemixMSPConfig).GetIpk() []byte
+	RevocationPk ParsedBytes //func (*msp.IdemixMSPConfig).GetRevocationPk() []byte
+	Epoch        int64       //func (*msp.IdemixMSPConfig).GetEpoch() int64
+}
+
+func (dimc *ParsedIdemixMSPConfig) DecodeIdemixMSPConfig(decoder *Decoder, idemixMSPConfig *msp.IdemixMSPConfig) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dimc.Name = idemixMSPConfig.GetName()
+	dimc.Ipk = decoder.bytes(idemixMSPConfig.GetIpk())
+	dimc.RevocationPk = decoder.bytes(idemixMSPConfig.GetRevocationPk())
+	dimc.Epoch = idemixMSPConfig.GetEpoch()
+
+	logger.Debug("DecodedIdemixMSPConfig", "value", dimc)
+
+	return nil
+}
+
+type ParsedBatchSize struct {
+	// *orderer.BatchSize
+	MaxMessageCount   uint32 //func (*orderer.BatchSize).GetMaxMessageCount() uint32
+	AbsoluteMaxBytes  uint32 //func (*orderer.BatchSize).GetAbsoluteMaxBytes() uint32
+	PreferredMaxBytes uint32 //func (*orderer.BatchSize).GetPreferredMaxBytes() uint32
+}
+
+func (dbs *ParsedBatchSize) DecodeBatchSize(decoder *Decoder, batchSize *orderer.BatchSize) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dbs.MaxMessageCount = batchSize.GetMaxMessageCount()
+	dbs.AbsoluteMaxBytes = batchSize.GetAbsoluteMaxBytes()
+	dbs.PreferredMaxBytes = batchSize.GetPreferredMaxBytes()
+
+	logger.Debug("DecodedBatchSize", "value", dbs)
+
+	return nil
+}
+
+type ParsedBatchTimeout struct {
+	// *orderer.BatchTimeout
+	Timeout string //func (*orderer.BatchTimeout).GetTimeout() string
+}
+
+func (dbt *ParsedBatchTimeout) DecodeBatchTimeout(decoder *Decoder, batchTimeout *orderer.BatchTimeout) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dbt.Timeout = batchTimeout.GetTimeout()
+
+	logger.Debug("DecodedBatchTimeout", "value", dbt)
+
+	return nil
+}
+
+type ParsedConsensusType struct {
+	// *orderer.ConsensusType
+	Type     string           //func (*orderer.ConsensusType).GetType() string
+	Metadata interface{}      //func (*orderer.ConsensusType).GetMetadata() []byte, decoded according to Type
+	State    string           //func (*orderer.ConsensusType).GetState() orderer.ConsensusType_State
+	Failures []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dct *ParsedConsensusType) DecodeConsensusType(decoder *Decoder, consensusType *orderer.ConsensusType) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dct.Type = consensusType.GetType()
+
+	decodedMetadata, err := decodeConsensusTypeMetadata(decoder, consensusType.GetType(), consensusType.GetMetadata())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dct.Failures, prefixPath("Metadata", err))
+		if err != nil {
+			return err
+		}
+		// keep the raw bytes, like for the metadata of other consensus types
+		decodedMetadata = decoder.bytes(consensusType.GetMetadata())
+	}
+	dct.Metadata = decodedMetadata
+
+	dct.State = consensusType.GetState().String()
+
+	logger.Debug("DecodedConsensusType", "value", dct)
+
+	return nil
+}
+
+type ParsedOrdererAddresses struct {
+	// *common.OrdererAddresses
+	Addresses []string //func (*common.OrdererAddresses).GetAddresses() []string
+}
+
+func (doa *ParsedOrdererAddresses) DecodeOrdererAddresses(decoder *Decoder, ordererAddresses *common.OrdererAddresses) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	doa.Addresses = ordererAddresses.GetAddresses()
+
+	logger.Debug("DecodedOrdererAddresses", "value", doa)
+
+	return nil
+}
+
+type ParsedAnchorPeers struct {
+	// *peer.AnchorPeers
+	AnchorPeers []*ParsedAnchorPeer //func (*peer.AnchorPeers).GetAnchorPeers() []*peer.AnchorPeer
+}
+
+func (daps *ParsedAnchorPeers) DecodeAnchorPeers(decoder *Decoder, anchorPeers *peer.AnchorPeers) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedAnchorPeers := []*ParsedAnchorPeer{}
+	for i, anchorPeer := range anchorPeers.GetAnchorPeers() {
+		decodedAnchorPeer := &ParsedAnchorPeer{}
+		err := decodedAnchorPeer.DecodeAnchorPeer(decoder, anchorPeer)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("AnchorPeers[%d]", i), err)
+		}
+		decodedAnchorPeers = append(decodedAnchorPeers, decodedAnchorPeer)
+	}
+	daps.AnchorPeers = decodedAnchorPeers
+
+	logger.Debug("DecodedAnchorPeers", "value", daps)
+
+	return nil
+}
+
+type ParsedAnchorPeer struct {
+	// *peer.AnchorPeer
+	Host string //func (*peer.AnchorPeer).GetHost() string
+	Port int32  //func (*peer.AnchorPeer).GetPort() int32
+}
+
+func (dap *ParsedAnchorPeer) DecodeAnchorPeer(decoder *Decoder, anchorPeer *peer.AnchorPeer) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dap.Host = anchorPeer.GetHost()
+	dap.Port = anchorPeer.GetPort()
+
+	logger.Debug("DecodedAnchorPeer", "value", dap)
+
+	return nil
+}
+
+type ParsedCapabilities struct {
+	// *common.Capabilities
+	Capabilities []string //func (*common.Capabilities).GetCapabilities() map[string]*common.Capability
+}
+
+func (dc *ParsedCapabilities) DecodeCapabilities(decoder *Decoder, capabilities *common.Capabilities) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	// common.Capability carries no fields, only the names are meaningful
+	decodedCapabilities := []string{}
+	for name := range capabilities.GetCapabilities() {
+		decodedCapabilities = append(decodedCapabilities, name)
+	}
+	sort.Strings(decodedCapabilities)
+	dc.Capabilities = decodedCapabilities
+
+	logger.Debug("DecodedCapabilities", "value", dc)
+
+	return nil
+}
+
+type ParsedACLs struct {
+	// *peer.ACLs
+	Acls map[string]string //func (*peer.ACLs).GetAcls() map[string]*peer.APIResource, resource name to policy reference
+}
+
+func (da *ParsedACLs) DecodeACLs(decoder *Decoder, acls *peer.ACLs) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedAcls := map[string]string{}
+	for resource, apiResource := range acls.GetAcls() {
+		decodedAcls[resource] = apiResource.GetPolicyRef()
+	}
+	da.Acls = decodedAcls
+
+	logger.Debug("DecodedACLs", "value", da)
+
+	return nil
+}
+
+type ParsedHashingAlgorithm struct {
+	// *common.HashingAlgorithm
+	Name string //func (*common.HashingAlgorithm).GetName() string
+}
+
+func (dha *ParsedHashingAlgorithm) DecodeHashingAlgorithm(decoder *Decoder, hashingAlgorithm *common.HashingAlgorithm) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dha.Name = hashingAlgorithm.GetName()
+
+	logger.Debug("DecodedHashingAlgorithm", "value", dha)
+
+	return nil
+}
+
+type ParsedBlockDataHashingStructure struct {
+	// *common.BlockDataHashingStructure
+	Width uint32 //func (*common.BlockDataHashingStructure).GetWidth() uint32
+}
+
+func (dbdhs *ParsedBlockDataHashingStructure) DecodeBlockDataHashingStructure(decoder *Decoder, blockDataHashingStructure *common.BlockDataHashingStructure) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dbdhs.Width = blockDataHashingStructure.GetWidth()
+
+	logger.Debug("DecodedBlockDataHashingStructure", "value", dbdhs)
+
+	return nil
+}
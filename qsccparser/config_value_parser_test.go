@@ -0,0 +1,99 @@
+package qsccparser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestDecodeConfigValueByKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		value []byte
+		json  string
+	}{
+		{BatchSizeKey, testMarshal(t, &orderer.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 103809024, PreferredMaxBytes: 524288}),
+			`{"MaxMessageCount":10,"AbsoluteMaxBytes":103809024,"PreferredMaxBytes":524288}`},
+		{BatchTimeoutKey, testMarshal(t, &orderer.BatchTimeout{Timeout: "2s"}), `{"Timeout":"2s"}`},
+		{ConsensusTypeKey, testMarshal(t, &orderer.ConsensusType{Type: "solo", State: orderer.ConsensusType_STATE_MAINTENANCE}),
+			`{"Type":"solo","Metadata":null,"State":"STATE_MAINTENANCE"}`},
+		{OrdererAddressesKey, testMarshal(t, &common.OrdererAddresses{Addresses: []string{"orderer0.example.com:7050"}}), `{"Addresses":["orderer0.example.com:7050"]}`},
+		{EndpointsKey, testMarshal(t, &common.OrdererAddresses{Addresses: []string{"orderer0.org1.example.com:7050"}}), `{"Addresses":["orderer0.org1.example.com:7050"]}`},
+		{AnchorPeersKey, testMarshal(t, &peer.AnchorPeers{AnchorPeers: []*peer.AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}}),
+			`{"AnchorPeers":[{"Host":"peer0.org1.example.com","Port":7051}]}`},
+		{CapabilitiesKey, testMarshal(t, &common.Capabilities{Capabilities: map[string]*common.Capability{"V2_5": {}, "V2_0": {}}}), `{"Capabilities":["V2_0","V2_5"]}`},
+		{ACLsKey, testMarshal(t, &peer.ACLs{Acls: map[string]*peer.APIResource{"qscc/GetBlockByNumber": {PolicyRef: "/Channel/Application/Readers"}}}),
+			`{"Acls":{"qscc/GetBlockByNumber":"/Channel/Application/Readers"}}`},
+		{HashingAlgorithmKey, testMarshal(t, &common.HashingAlgorithm{Name: "SHA256"}), `{"Name":"SHA256"}`},
+		{BlockDataHashingStructureKey, testMarshal(t, &common.BlockDataHashingStructure{Width: 4294967295}), `{"Width":4294967295}`},
+		{MSPKey, testMarshal(t, &msp.MSPConfig{Type: 1, Config: testMarshal(t, &msp.IdemixMSPConfig{Name: "IdemixMSP", Epoch: 2})}),
+			`{"Type":"IDEMIX","Config":{"Name":"IdemixMSP","Ipk":null,"RevocationPk":null,"Epoch":2}}`},
+		// values under keys it does not know are kept as they are
+		{"Unknown", []byte("value"), `"dmFsdWU="`},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			value, err := decodeConfigValueByKey(nil, test.key, test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, test.json)
+			}
+		})
+	}
+}
+
+func TestDecodeMSPConfigValue(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	organization := testChannelGroup(t, ca).Groups["Application"].Groups["Org1MSP"]
+
+	value, err := decodeConfigValueByKey(nil, MSPKey, organization.Values[MSPKey].Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mspConfig, ok := value.(*ParsedMSPConfig)
+	if !ok {
+		t.Fatalf("decodeConfigValueByKey() = %T, want a ParsedMSPConfig", value)
+	}
+	fabricMSPConfig, ok := mspConfig.Config.(*ParsedFabricMSPConfig)
+	if mspConfig.Type != "FABRIC" || !ok {
+		t.Fatalf("MSPConfig Type = %s, Config = %T", mspConfig.Type, mspConfig.Config)
+	}
+	if fabricMSPConfig.Name != "Org1MSP" || len(fabricMSPConfig.RootCerts) != 1 || fabricMSPConfig.RootCerts[0].Subject != ca.cert.Subject.String() {
+		t.Errorf("FabricMSPConfig = %+v", fabricMSPConfig)
+	}
+	if nodeOUs := fabricMSPConfig.FabricNodeOus; nodeOUs == nil || !nodeOUs.Enable || nodeOUs.PeerOuIdentifier.OrganizationalUnitIdentifier != "peer" {
+		t.Errorf("FabricNodeOus = %+v", nodeOUs)
+	}
+}
+
+func TestDecodeConfigValueMalformed(t *testing.T) {
+	channelGroup := &common.ConfigGroup{Values: map[string]*common.ConfigValue{BatchSizeKey: {Value: []byte{0x0a, 0xff}, ModPolicy: "Admins"}}}
+	configEnvelope := testMarshal(t, &common.ConfigEnvelope{Config: &common.Config{ChannelGroup: channelGroup}})
+	block := testBlock(t, 0, nil, testEnvelope(t, common.HeaderType_CONFIG, configEnvelope))
+
+	path := "Data.Data[0].Payload.Data.Config.ChannelGroup.Values[BatchSize].Value"
+	_, err := DecodeBlock(block)
+	if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != path || decodeErr.Message != "orderer.BatchSize" {
+		t.Errorf("DecodeBlock() = %v, want the DecodeError of the BatchSize", err)
+	}
+
+	// a lenient Decoder keeps the raw bytes of the value, like for unknown keys
+	decodedBlock, _ := (&Decoder{Lenient: true}).DecodeBlock(block)
+	configValue := decodedBlock.Data.Data[0].Payload.Data.(*ParsedConfigEnvelope).Config.ChannelGroup.Values[BatchSizeKey]
+	if raw, ok := configValue.Value.(ParsedBytes); !ok || raw.Length != 2 || configValue.ModPolicy != "Admins" || len(configValue.Failures) != 1 {
+		t.Errorf("Values[BatchSize] = %+v, want the raw bytes with the failure", configValue)
+	}
+	if len(decodedBlock.Warnings) != 1 {
+		t.Errorf("Warnings = %v, want the one of %s", decodedBlock.Warnings, path)
+	}
+}
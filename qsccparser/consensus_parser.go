@@ -0,0 +1,450 @@
+package qsccparser
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/orderer/smartbft"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// orderer.ConsensusType.Type values with consensus specific metadata
+const (
+	EtcdraftConsensusType = "etcdraft"
+	BFTConsensusType      = "BFT"
+)
+
+// decodeConsensusTypeMetadata decodes orderer.ConsensusType.Metadata according to the consensus type name.
+// Metadata of other consensus types is returned as raw bytes.
+func decodeConsensusTypeMetadata(decoder *Decoder, consensusType string, metadata []byte) (interface{}, error) {
+	logger := decoder.logger()
+
+	switch consensusType {
+	case EtcdraftConsensusType:
+		configMetadata := &etcdraft.ConfigMetadata{}
+		err := configMetadata.XXX_Unmarshal(metadata)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", configMetadata, metadata, err)
+		}
+
+		decodedEtcdraftConfigMetadata := &ParsedEtcdraftConfigMetadata{}
+		err = decodedEtcdraftConfigMetadata.DecodeEtcdraftConfigMetadata(decoder, configMetadata)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedEtcdraftConfigMetadata, nil
+	case BFTConsensusType:
+		options := &smartbft.Options{}
+		err := options.XXX_Unmarshal(metadata)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", options, metadata, err)
+		}
+
+		decodedSmartBFTOptions := &ParsedSmartBFTOptions{}
+		err = decodedSmartBFTOptions.DecodeSmartBFTOptions(decoder, options)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedSmartBFTOptions, nil
+	}
+
+	return decoder.bytes(metadata), nil
+}
+
+// decodeConsenterMetadata decodes common.OrdererBlockMetadata.ConsenterMetadata according to the consensus type name.
+// Metadata of other or unknown consensus types is returned as raw bytes.
+func decodeConsenterMetadata(decoder *Decoder, consensusType string, consenterMetadata []byte) (interface{}, error) {
+	logger := decoder.logger()
+
+	switch consensusType {
+	case EtcdraftConsensusType:
+		blockMetadata := &etcdraft.BlockMetadata{}
+		err := blockMetadata.XXX_Unmarshal(consenterMetadata)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			return nil, unmarshalError("", blockMetadata, consenterMetadata, err)
+		}
+
+		decodedEtcdraftBlockMetadata := &ParsedEtcdraftBlockMetadata{}
+		err = decodedEtcdraftBlockMetadata.DecodeEtcdraftBlockMetadata(decoder, blockMetadata)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedEtcdraftBlockMetadata, nil
+	case BFTConsensusType:
+		decodedViewMetadata := &ParsedViewMetadata{}
+		err := decodedViewMetadata.DecodeViewMetadata(decoder, consenterMetadata)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return nil, err
+		}
+		return decodedViewMetadata, nil
+	}
+
+	return decoder.bytes(consenterMetadata), nil
+}
+
+type ParsedEtcdraftConfigMetadata struct {
+	// *etcdraft.ConfigMetadata
+	Consenters []*ParsedEtcdraftConsenter //func (*etcdraft.ConfigMetadata).GetConsenters() []*etcdraft.Consenter
+	Options    *ParsedEtcdraftOptions     //func (*etcdraft.ConfigMetadata).GetOptions() *etcdraft.Options
+}
+
+func (decm *ParsedEtcdraftConfigMetadata) DecodeEtcdraftConfigMetadata(decoder *Decoder, configMetadata *etcdraft.ConfigMetadata) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedEtcdraftConsenters := []*ParsedEtcdraftConsenter{}
+	for i, consenter := range configMetadata.GetConsenters() {
+		decodedEtcdraftConsenter := &ParsedEtcdraftConsenter{}
+		err := decodedEtcdraftConsenter.DecodeEtcdraftConsenter(decoder, consenter)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Consenters[%d]", i), err)
+		}
+		decodedEtcdraftConsenters = append(decodedEtcdraftConsenters, decodedEtcdraftConsenter)
+	}
+	decm.Consenters = decodedEtcdraftConsenters
+
+	decodedEtcdraftOptions := &ParsedEtcdraftOptions{}
+	err := decodedEtcdraftOptions.DecodeEtcdraftOptions(decoder, configMetadata.GetOptions())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Options", err)
+	}
+	decm.Options = decodedEtcdraftOptions
+
+	logger.Debug("DecodedEtcdraftConfigMetadata", "value", decm)
+
+	return nil
+}
+
+type ParsedEtcdraftConsenter struct {
+	// *etcdraft.Consenter
+	Host          string         //func (*etcdraft.Consenter).GetHost() string
+	Port          uint32         //func (*etcdraft.Consenter).GetPort() uint32
+	ClientTlsCert *ParsedIdBytes //func (*etcdraft.Consenter).GetClientTlsCert() []byte
+	ServerTlsCert *ParsedIdBytes //func (*etcdraft.Consenter).GetServerTlsCert() []byte
+}
+
+func (dec *ParsedEtcdraftConsenter) DecodeEtcdraftConsenter(decoder *Decoder, consenter *etcdraft.Consenter) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dec.Host = consenter.GetHost()
+	dec.Port = consenter.GetPort()
+
+	decodedClientTlsCert := &ParsedIdBytes{}
+	err := decodedClientTlsCert.DecodeIdBytes(decoder, consenter.GetClientTlsCert())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ClientTlsCert", err)
+	}
+	dec.ClientTlsCert = decodedClientTlsCert
+
+	decodedServerTlsCert := &ParsedIdBytes{}
+	err = decodedServerTlsCert.DecodeIdBytes(decoder, consenter.GetServerTlsCert())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ServerTlsCert", err)
+	}
+	dec.ServerTlsCert = decodedServerTlsCert
+
+	logger.Debug("DecodedEtcdraftConsenter", "value", dec)
+
+	return nil
+}
+
+type ParsedEtcdraftOptions struct {
+	// *etcdraft.Options
+	TickInterval         string //func (*etcdraft.Options).GetTickInterval() string
+	ElectionTick         uint32 //func (*etcdraft.Options).GetElectionTick() uint32
+	HeartbeatTick        uint32 //func (*etcdraft.Options).GetHeartbeatTick() uint32
+	MaxInflightBlocks    uint32 //func (*etcdraft.Options).GetMaxInflightBlocks() uint32
+	SnapshotIntervalSize uint32 //func (*etcdraft.Options).GetSnapshotIntervalSize() uint32
+}
+
+func (deo *ParsedEtcdraftOptions) DecodeEtcdraftOptions(decoder *Decoder, options *etcdraft.Options) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	deo.TickInterval = options.GetTickInterval()
+	deo.ElectionTick = options.GetElectionTick()
+	deo.HeartbeatTick = options.GetHeartbeatTick()
+	deo.MaxInflightBlocks = options.GetMaxInflightBlocks()
+	deo.SnapshotIntervalSize = options.GetSnapshotIntervalSize()
+
+	logger.Debug("DecodedEtcdraftOptions", "value", deo)
+
+	return nil
+}
+
+type ParsedEtcdraftBlockMetadata struct {
+	// *etcdraft.BlockMetadata
+	ConsenterIds    []uint64 //func (*etcdraft.BlockMetadata).GetConsenterIds() []uint64
+	NextConsenterId uint64   //func (*etcdraft.BlockMetadata).GetNextConsenterId() uint64
+	RaftIndex       uint64   //func (*etcdraft.BlockMetadata).GetRaftIndex() uint64
+}
+
+func (debm *ParsedEtcdraftBlockMetadata) DecodeEtcdraftBlockMetadata(decoder *Decoder, blockMetadata *etcdraft.BlockMetadata) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	debm.ConsenterIds = blockMetadata.GetConsenterIds()
+	debm.NextConsenterId = blockMetadata.GetNextConsenterId()
+	debm.RaftIndex = blockMetadata.GetRaftIndex()
+
+	logger.Debug("DecodedEtcdraftBlockMetadata", "value", debm)
+
+	return nil
+}
+
+type ParsedSmartBFTOptions struct {
+	// *smartbft.Options
+	RequestBatchMaxCount      uint64 //func (*smartbft.Options).GetRequestBatchMaxCount() uint64
+	RequestBatchMaxBytes      uint64 //func (*smartbft.Options).GetRequestBatchMaxBytes() uint64
+	RequestBatchMaxInterval   string //func (*smartbft.Options).GetRequestBatchMaxInterval() string
+	IncomingMessageBufferSize uint64 //func (*smartbft.Options).GetIncomingMessageBufferSize() uint64
+	RequestPoolSize           uint64 //func (*smartbft.Options).GetRequestPoolSize() uint64
+	RequestForwardTimeout     string //func (*smartbft.Options).GetRequestForwardTimeout() string
+	RequestComplainTimeout    string //func (*smartbft.Options).GetRequestComplainTimeout() string
+	RequestAutoRemoveTimeout  string //func (*smartbft.Options).GetRequestAutoRemoveTimeout() string
+	RequestMaxBytes           uint64 //func (*smartbft.Options).GetRequestMaxBytes() uint64
+	ViewChangeResendInterval  string //func (*smartbft.Options).GetViewChangeResendInterval() string
+	ViewChangeTimeout         string //func (*smartbft.Options).GetViewChangeTimeout() string
+	LeaderHeartbeatTimeout    string //func (*smartbft.Options).GetLeaderHeartbeatTimeout() string
+	LeaderHeartbeatCount      uint64 //func (*smartbft.Options).GetLeaderHeartbeatCount() uint64
+	CollectTimeout            string //func (*smartbft.Options).GetCollectTimeout() string
+	SyncOnStart               bool   //func (*smartbft.Options).GetSyncOnStart() bool
+	SpeedUpViewChange         bool   //func (*smartbft.Options).GetSpeedUpViewChange() bool
+	LeaderRotation            string //func (*smartbft.Options).GetLeaderRotation() smartbft.Options_Rotation
+	DecisionsPerLeader        uint64 //func (*smartbft.Options).GetDecisionsPerLeader() uint64
+}
+
+func (dsbo *ParsedSmartBFTOptions) DecodeSmartBFTOptions(decoder *Decoder, options *smartbft.Options) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dsbo.RequestBatchMaxCount = options.GetRequestBatchMaxCount()
+	dsbo.RequestBatchMaxBytes = options.GetRequestBatchMaxBytes()
+	dsbo.RequestBatchMaxInterval = options.GetRequestBatchMaxInterval()
+	dsbo.IncomingMessageBufferSize = options.GetIncomingMessageBufferSize()
+	dsbo.RequestPoolSize = options.GetRequestPoolSize()
+	dsbo.RequestForwardTimeout = options.GetRequestForwardTimeout()
+	dsbo.RequestComplainTimeout = options.GetRequestComplainTimeout()
+	dsbo.RequestAutoRemoveTimeout = options.GetRequestAutoRemoveTimeout()
+	dsbo.RequestMaxBytes = options.GetRequestMaxBytes()
+	dsbo.ViewChangeResendInterval = options.GetViewChangeResendInterval()
+	dsbo.ViewChangeTimeout = options.GetViewChangeTimeout()
+	dsbo.LeaderHeartbeatTimeout = options.GetLeaderHeartbeatTimeout()
+	dsbo.LeaderHeartbeatCount = options.GetLeaderHeartbeatCount()
+	dsbo.CollectTimeout = options.GetCollectTimeout()
+	dsbo.SyncOnStart = options.GetSyncOnStart()
+	dsbo.SpeedUpViewChange = options.GetSpeedUpViewChange()
+	dsbo.LeaderRotation = options.GetLeaderRotation().String()
+	dsbo.DecisionsPerLeader = options.GetDecisionsPerLeader()
+
+	logger.Debug("DecodedSmartBFTOptions", "value", dsbo)
+
+	return nil
+}
+
+type ParsedViewMetadata struct {
+	// smartbftprotos.ViewMetadata of github.com/hyperledger-labs/SmartBFT, which fabric-protos-go does not ship
+	ViewId                    uint64           // field 1
+	LatestSequence            uint64           // field 2
+	DecisionsInView           uint64           // field 3
+	BlackList                 []uint64         // field 4
+	PrevCommitSignatureDigest ParsedBytes      // field 5
+	Failures                  []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dvm *ParsedViewMetadata) DecodeViewMetadata(decoder *Decoder, viewMetadata []byte) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	input := viewMetadata
+
+	blackList := []uint64{}
+	for len(viewMetadata) > 0 {
+		number, wireType, n := protowire.ConsumeTag(viewMetadata)
+		if n < 0 {
+			err := protowire.ParseError(n)
+			logger.Warn("cannot decode", "err", err)
+			return decoder.fail(&dvm.Failures, decodeError("", "smartbftprotos.ViewMetadata", input, err))
+		}
+		viewMetadata = viewMetadata[n:]
+
+		switch {
+		case wireType == protowire.VarintType && number <= 4:
+			value, n := protowire.ConsumeVarint(viewMetadata)
+			if n < 0 {
+				err := protowire.ParseError(n)
+				logger.Warn("cannot decode", "err", err)
+				return decoder.fail(&dvm.Failures, decodeError("", "smartbftprotos.ViewMetadata", input, err))
+			}
+			viewMetadata = viewMetadata[n:]
+
+			switch number {
+			case 1:
+				dvm.ViewId = value
+			case 2:
+				dvm.LatestSequence = value
+			case 3:
+				dvm.DecisionsInView = value
+			case 4:
+				blackList = append(blackList, value)
+			}
+		case wireType == protowire.BytesType && (number == 4 || number == 5):
+			value, n := protowire.ConsumeBytes(viewMetadata)
+			if n < 0 {
+				err := protowire.ParseError(n)
+				logger.Warn("cannot decode", "err", err)
+				return decoder.fail(&dvm.Failures, decodeError("", "smartbftprotos.ViewMetadata", input, err))
+			}
+			viewMetadata = viewMetadata[n:]
+
+			if number == 5 {
+				dvm.PrevCommitSignatureDigest = decoder.bytes(value)
+				continue
+			}
+			// packed black_list
+			for len(value) > 0 {
+				id, n := protowire.ConsumeVarint(value)
+				if n < 0 {
+					err := protowire.ParseError(n)
+					logger.Warn("cannot decode", "err", err)
+					return decoder.fail(&dvm.Failures, decodeError("", "smartbftprotos.ViewMetadata", input, err))
+				}
+				value = value[n:]
+				blackList = append(blackList, id)
+			}
+		default:
+			n := protowire.ConsumeFieldValue(number, wireType, viewMetadata)
+			if n < 0 {
+				err := fmt.Errorf("field %d of ViewMetadata: %w", number, protowire.ParseError(n))
+				logger.Warn("cannot decode", "err", err)
+				return decoder.fail(&dvm.Failures, decodeError("", "smartbftprotos.ViewMetadata", input, err))
+			}
+			viewMetadata = viewMetadata[n:]
+		}
+	}
+	dvm.BlackList = blackList
+
+	logger.Debug("DecodedViewMetadata", "value", dvm)
+
+	return nil
+}
+
+type ParsedOrderers struct {
+	// *common.Orderers
+	ConsenterMapping []*ParsedConsenter //func (*common.Orderers).GetConsenterMapping() []*common.Consenter
+}
+
+func (do *ParsedOrderers) DecodeOrderers(decoder *Decoder, orderers *common.Orderers) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedConsenters := []*ParsedConsenter{}
+	for i, consenter := range orderers.GetConsenterMapping() {
+		decodedConsenter := &ParsedConsenter{}
+		err := decodedConsenter.DecodeConsenter(decoder, consenter)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("ConsenterMapping[%d]", i), err)
+		}
+		decodedConsenters = append(decodedConsenters, decodedConsenter)
+	}
+	do.ConsenterMapping = decodedConsenters
+
+	logger.Debug("DecodedOrderers", "value", do)
+
+	return nil
+}
+
+type ParsedConsenter struct {
+	// *common.Consenter
+	Id            uint32         //func (*common.Consenter).GetId() uint32
+	Host          string         //func (*common.Consenter).GetHost() string
+	Port          uint32         //func (*common.Consenter).GetPort() uint32
+	MspId         string         //func (*common.Consenter).GetMspId() string
+	Identity      *ParsedIdBytes //func (*common.Consenter).GetIdentity() []byte
+	ClientTlsCert *ParsedIdBytes //func (*common.Consenter).GetClientTlsCert() []byte
+	ServerTlsCert *ParsedIdBytes //func (*common.Consenter).GetServerTlsCert() []byte
+}
+
+func (dc *ParsedConsenter) DecodeConsenter(decoder *Decoder, consenter *common.Consenter) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dc.Id = consenter.GetId()
+	dc.Host = consenter.GetHost()
+	dc.Port = consenter.GetPort()
+	dc.MspId = consenter.GetMspId()
+
+	decodedIdentity := &ParsedIdBytes{}
+	err := decodedIdentity.DecodeIdBytes(decoder, consenter.GetIdentity())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Identity", err)
+	}
+	dc.Identity = decodedIdentity
+
+	decodedClientTlsCert := &ParsedIdBytes{}
+	err = decodedClientTlsCert.DecodeIdBytes(decoder, consenter.GetClientTlsCert())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ClientTlsCert", err)
+	}
+	dc.ClientTlsCert = decodedClientTlsCert
+
+	decodedServerTlsCert := &ParsedIdBytes{}
+	err = decodedServerTlsCert.DecodeIdBytes(decoder, consenter.GetServerTlsCert())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ServerTlsCert", err)
+	}
+	dc.ServerTlsCert = decodedServerTlsCert
+
+	logger.Debug("DecodedConsenter", "value", dc)
+
+	return nil
+}
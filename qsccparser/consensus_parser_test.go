@@ -0,0 +1,201 @@
+package qsccparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/orderer/smartbft"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDecodeConsensusTypeMetadata(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	raft := testChannelGroup(t, ca).Groups["Orderer"].Values[ConsensusTypeKey].Value
+	bft := testMarshal(t, &orderer.ConsensusType{Type: BFTConsensusType, Metadata: testMarshal(t, &smartbft.Options{
+		RequestBatchMaxCount: 100,
+		RequestBatchMaxBytes: 10485760,
+		ViewChangeTimeout:    "20s",
+		SyncOnStart:          true,
+		LeaderRotation:       smartbft.Options_ROTATION_ON,
+		DecisionsPerLeader:   3,
+	})})
+
+	t.Run("etcdraft", func(t *testing.T) {
+		value, err := decodeConfigValueByKey(nil, ConsensusTypeKey, raft)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metadata, ok := value.(*ParsedConsensusType).Metadata.(*ParsedEtcdraftConfigMetadata)
+		if !ok {
+			t.Fatalf("Metadata = %T, want the etcdraft ConfigMetadata", value.(*ParsedConsensusType).Metadata)
+		}
+		if len(metadata.Consenters) != 1 {
+			t.Fatalf("Consenters = %v, want 1", metadata.Consenters)
+		}
+		consenter := metadata.Consenters[0]
+		if consenter.Host != "orderer0.example.com" || consenter.Port != 7050 || consenter.ClientTlsCert.Subject != ca.cert.Subject.String() || consenter.ServerTlsCert.Subject != ca.cert.Subject.String() {
+			t.Errorf("Consenters[0] = %+v", consenter)
+		}
+		if want := (&ParsedEtcdraftOptions{TickInterval: "500ms", ElectionTick: 10, HeartbeatTick: 1}); !reflect.DeepEqual(metadata.Options, want) {
+			t.Errorf("Options = %+v, want %+v", metadata.Options, want)
+		}
+	})
+
+	t.Run("BFT", func(t *testing.T) {
+		value, err := decodeConfigValueByKey(nil, ConsensusTypeKey, bft)
+		if err != nil {
+			t.Fatal(err)
+		}
+		options, ok := value.(*ParsedConsensusType).Metadata.(*ParsedSmartBFTOptions)
+		if !ok {
+			t.Fatalf("Metadata = %T, want the SmartBFT Options", value.(*ParsedConsensusType).Metadata)
+		}
+		if options.RequestBatchMaxCount != 100 || options.RequestBatchMaxBytes != 10485760 || options.ViewChangeTimeout != "20s" ||
+			!options.SyncOnStart || options.LeaderRotation != "ROTATION_ON" || options.DecisionsPerLeader != 3 {
+			t.Errorf("Options = %+v", options)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, consensusType := range []string{EtcdraftConsensusType, BFTConsensusType} {
+			malformed := testMarshal(t, &orderer.ConsensusType{Type: consensusType, Metadata: []byte{0x0a, 0xff}})
+			_, err := decodeConfigValueByKey(nil, ConsensusTypeKey, malformed)
+			if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "Metadata" {
+				t.Errorf("%s: decodeConfigValueByKey() = %v, want the DecodeError of the Metadata", consensusType, err)
+			}
+
+			// a lenient Decoder keeps the raw bytes, like for other consensus types
+			value, err := decodeConfigValueByKey(&Decoder{Lenient: true}, ConsensusTypeKey, malformed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decodedConsensusType := value.(*ParsedConsensusType)
+			if raw, ok := decodedConsensusType.Metadata.(ParsedBytes); !ok || raw.Length != 2 || len(decodedConsensusType.Failures) != 1 {
+				t.Errorf("%s: ConsensusType = %+v, want the raw Metadata with the failure", consensusType, decodedConsensusType)
+			}
+		}
+	})
+}
+
+func TestDecodeConsenterMetadata(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	raftMetadata := testMarshal(t, &etcdraft.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4, RaftIndex: 42})
+
+	var viewMetadata []byte
+	viewMetadata = protowire.AppendTag(viewMetadata, 1, protowire.VarintType)
+	viewMetadata = protowire.AppendVarint(viewMetadata, 3)
+	viewMetadata = protowire.AppendTag(viewMetadata, 2, protowire.VarintType)
+	viewMetadata = protowire.AppendVarint(viewMetadata, 100)
+	viewMetadata = protowire.AppendTag(viewMetadata, 3, protowire.VarintType)
+	viewMetadata = protowire.AppendVarint(viewMetadata, 5)
+	// the black list may be packed or not
+	viewMetadata = protowire.AppendTag(viewMetadata, 4, protowire.BytesType)
+	viewMetadata = protowire.AppendBytes(viewMetadata, protowire.AppendVarint(protowire.AppendVarint(nil, 1), 2))
+	viewMetadata = protowire.AppendTag(viewMetadata, 4, protowire.VarintType)
+	viewMetadata = protowire.AppendVarint(viewMetadata, 7)
+	viewMetadata = protowire.AppendTag(viewMetadata, 5, protowire.BytesType)
+	viewMetadata = protowire.AppendBytes(viewMetadata, []byte("digest"))
+	// fields of later versions are skipped
+	viewMetadata = protowire.AppendTag(viewMetadata, 9, protowire.VarintType)
+	viewMetadata = protowire.AppendVarint(viewMetadata, 1)
+
+	testMetadata := func(consenterMetadata []byte) [][]byte {
+		value := testMarshal(t, &common.OrdererBlockMetadata{LastConfig: &common.LastConfig{Index: 0}, ConsenterMetadata: consenterMetadata})
+		return [][]byte{testMarshal(t, &common.Metadata{Value: value})}
+	}
+
+	tests := []struct {
+		name          string
+		consensusType string
+		metadata      []byte
+		want          interface{}
+	}{
+		{"etcdraft", EtcdraftConsensusType, raftMetadata, &ParsedEtcdraftBlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4, RaftIndex: 42}},
+		{"BFT", BFTConsensusType, viewMetadata, &ParsedViewMetadata{ViewId: 3, LatestSequence: 100, DecisionsInView: 5, BlackList: []uint64{1, 2, 7}, PrevCommitSignatureDigest: ParsedBytes{Bytes: []byte("digest"), Length: 6}}},
+		// without a consensus type, the metadata of the block is kept as it is
+		{"unknown", "", raftMetadata, ParsedBytes{Bytes: raftMetadata, Length: len(raftMetadata)}},
+		{"other", "solo", raftMetadata, ParsedBytes{Bytes: raftMetadata, Length: len(raftMetadata)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decodedBlock, err := DecodeBlockWithConsensusType(testBlock(t, 9, testMetadata(test.metadata)), test.consensusType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if consenterMetadata := decodedBlock.Metadata.Signatures.Value.ConsenterMetadata; !reflect.DeepEqual(consenterMetadata, test.want) {
+				t.Errorf("ConsenterMetadata = %+v, want %+v", consenterMetadata, test.want)
+			}
+		})
+	}
+
+	t.Run("config block", func(t *testing.T) {
+		// the consensus type of a config block is taken from its own config
+		configEnvelope := testMarshal(t, &common.ConfigEnvelope{Config: &common.Config{ChannelGroup: testChannelGroup(t, ca)}})
+		block := testBlock(t, 0, testMetadata(raftMetadata), testEnvelope(t, common.HeaderType_CONFIG, configEnvelope))
+		decodedBlock, err := DecodeBlock(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := decodedBlock.Metadata.Signatures.Value.ConsenterMetadata.(*ParsedEtcdraftBlockMetadata); !ok {
+			t.Errorf("ConsenterMetadata = %T, want the etcdraft BlockMetadata", decodedBlock.Metadata.Signatures.Value.ConsenterMetadata)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for consensusType, message := range map[string]string{EtcdraftConsensusType: "etcdraft.BlockMetadata", BFTConsensusType: "smartbftprotos.ViewMetadata"} {
+			block := testBlock(t, 9, testMetadata([]byte{0x08}))
+			_, err := DecodeBlockWithConsensusType(block, consensusType)
+			if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "Metadata.Signatures.Value.ConsenterMetadata" || decodeErr.Message != message {
+				t.Errorf("%s: DecodeBlockWithConsensusType() = %v, want the DecodeError of the %s", consensusType, err, message)
+			}
+
+			decodedBlock, _ := (&Decoder{Lenient: true}).DecodeBlockWithConsensusType(block, consensusType)
+			ordererBlockMetadata := decodedBlock.Metadata.Signatures.Value
+			switch consenterMetadata := ordererBlockMetadata.ConsenterMetadata.(type) {
+			case ParsedBytes:
+				// a lenient Decoder keeps the raw bytes of what it cannot unmarshal, like for other consensus types
+				if consensusType != EtcdraftConsensusType || consenterMetadata.Length != 1 || len(ordererBlockMetadata.Failures) != 1 {
+					t.Errorf("%s: OrdererBlockMetadata = %+v, want the raw ConsenterMetadata with the failure", consensusType, ordererBlockMetadata)
+				}
+			case *ParsedViewMetadata:
+				// and records the failures of the ViewMetadata it reads itself at the ViewMetadata
+				if consensusType != BFTConsensusType || len(consenterMetadata.Failures) != 1 || consenterMetadata.Failures[0].Raw.Length != 1 {
+					t.Errorf("%s: ViewMetadata = %+v, want the failure", consensusType, consenterMetadata)
+				}
+			default:
+				t.Errorf("%s: ConsenterMetadata = %T", consensusType, consenterMetadata)
+			}
+			if len(decodedBlock.Warnings) != 1 {
+				t.Errorf("%s: Warnings = %v, want 1", consensusType, decodedBlock.Warnings)
+			}
+		}
+	})
+}
+
+func TestDecodeOrderers(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	key := newTestKey(t)
+	orderer0 := ca.issue(t, "orderer0", &key.PublicKey, "orderer")
+	orderers := testMarshal(t, &common.Orderers{ConsenterMapping: []*common.Consenter{
+		{Id: 1, Host: "orderer0.example.com", Port: 7050, MspId: "OrdererMSP", Identity: orderer0.Raw, ClientTlsCert: ca.cert.Raw, ServerTlsCert: ca.cert.Raw},
+	}})
+
+	value, err := decodeConfigValueByKey(nil, OrderersKey, orderers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedOrderers, ok := value.(*ParsedOrderers)
+	if !ok || len(decodedOrderers.ConsenterMapping) != 1 {
+		t.Fatalf("decodeConfigValueByKey() = %+v, want the Orderers with 1 consenter", value)
+	}
+	consenter := decodedOrderers.ConsenterMapping[0]
+	if consenter.Id != 1 || consenter.Host != "orderer0.example.com" || consenter.Port != 7050 || consenter.MspId != "OrdererMSP" {
+		t.Errorf("ConsenterMapping[0] = %+v", consenter)
+	}
+	if consenter.Identity.Subject != orderer0.Subject.String() || consenter.ClientTlsCert.Subject != ca.cert.Subject.String() || consenter.ServerTlsCert.Subject != ca.cert.Subject.String() {
+		t.Errorf("ConsenterMapping[0] Identity = %+v, ClientTlsCert = %+v, ServerTlsCert = %+v", consenter.Identity, consenter.ClientTlsCert, consenter.ServerTlsCert)
+	}
+}
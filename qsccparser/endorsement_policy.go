@@ -0,0 +1,403 @@
+package qsccparser
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// ParsePolicy parses a signature policy in the syntax of the peer CLI, e.g. "AND('Org1MSP.peer', OR('Org2MSP.admin', 'Org3MSP.member'))"
+// or "OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')". The roles are member, admin, client, peer and orderer.
+func ParsePolicy(expression string) (*common.SignaturePolicyEnvelope, error) {
+	parser := &policyParser{input: expression}
+	rule, err := parser.parseRule()
+	if err != nil {
+		return nil, err
+	}
+	parser.skipSpaces()
+	if parser.pos < len(parser.input) {
+		return nil, parser.errorf("unexpected %q", parser.input[parser.pos:])
+	}
+	return &common.SignaturePolicyEnvelope{Version: 0, Rule: rule, Identities: parser.identities}, nil
+}
+
+var policyPrincipalRegexp = regexp.MustCompile(`^([[:alnum:].-]+)[.](admin|member|client|peer|orderer)$`)
+
+var policyRoles = map[string]msp.MSPRole_MSPRoleType{
+	"member":  msp.MSPRole_MEMBER,
+	"admin":   msp.MSPRole_ADMIN,
+	"client":  msp.MSPRole_CLIENT,
+	"peer":    msp.MSPRole_PEER,
+	"orderer": msp.MSPRole_ORDERER,
+}
+
+type policyParser struct {
+	input      string
+	pos        int
+	identities []*msp.MSPPrincipal
+	principals []string // the principals of identities, e.g. Org1MSP.peer
+}
+
+func (p *policyParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("policy %q at %d: %s", p.input, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *policyParser) skipSpaces() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\n\r", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// token returns the next name, number or quoted principal, with the quotes removed
+func (p *policyParser) token() string {
+	p.skipSpaces()
+	if p.pos < len(p.input) && (p.input[p.pos] == '\'' || p.input[p.pos] == '"') {
+		quote := p.input[p.pos]
+		end := strings.IndexByte(p.input[p.pos+1:], quote)
+		if end < 0 {
+			return ""
+		}
+		token := p.input[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return token
+	}
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(" \t\n\r(),", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *policyParser) expect(c byte) error {
+	p.skipSpaces()
+	if p.pos >= len(p.input) || p.input[p.pos] != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *policyParser) parseRule() (*common.SignaturePolicy, error) {
+	token := p.token()
+	switch strings.ToLower(token) {
+	case "and", "or", "outof":
+		err := p.expect('(')
+		if err != nil {
+			return nil, err
+		}
+		n := -1
+		if strings.ToLower(token) == "outof" {
+			n, err = strconv.Atoi(p.token())
+			if err != nil {
+				return nil, p.errorf("OutOf needs a number first")
+			}
+			if n < 1 {
+				return nil, p.errorf("OutOf needs at least 1 rule, not %d", n)
+			}
+			err = p.expect(',')
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rules := []*common.SignaturePolicy{}
+		for {
+			rule, err := p.parseRule()
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+			p.skipSpaces()
+			if p.pos < len(p.input) && p.input[p.pos] == ',' {
+				p.pos++
+				continue
+			}
+			break
+		}
+		err = p.expect(')')
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(token) {
+		case "outof":
+			if n > len(rules) {
+				return nil, p.errorf("OutOf needs %d rules but has %d", n, len(rules))
+			}
+		case "and":
+			n = len(rules)
+		case "or":
+			n = 1
+		}
+		return &common.SignaturePolicy{Type: &common.SignaturePolicy_NOutOf_{NOutOf: &common.SignaturePolicy_NOutOf{N: int32(n), Rules: rules}}}, nil
+	default:
+		match := policyPrincipalRegexp.FindStringSubmatch(token)
+		if match == nil {
+			return nil, p.errorf("%q is not a principal like 'Org1MSP.peer'", token)
+		}
+		return &common.SignaturePolicy{Type: &common.SignaturePolicy_SignedBy{SignedBy: p.identity(match[1], policyRoles[match[2]])}}, nil
+	}
+}
+
+// identity returns the index of the role principal in identities, adding it when it is new
+func (p *policyParser) identity(mspid string, role msp.MSPRole_MSPRoleType) int32 {
+	principal := mspid + "." + strings.ToLower(role.String())
+	for i, known := range p.principals {
+		if known == principal {
+			return int32(i)
+		}
+	}
+	mspRole, _ := (&msp.MSPRole{MspIdentifier: mspid, Role: role}).XXX_Marshal(nil, false)
+	p.identities = append(p.identities, &msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: mspRole})
+	p.principals = append(p.principals, principal)
+	return int32(len(p.identities) - 1)
+}
+
+// ParsedPolicyEvaluation tells whether the endorsements of a chaincode action satisfy the EndorsementPolicy of a Decoder.
+// Like a Fabric peer, each endorser counts once and only for one principal, and endorsements with an invalid signature don't count.
+// The signatures are verified for the evaluation even when the Decoder does not VerifySignatures, so the endorsements
+// of Idemix identities, whose signatures cannot be verified, never count.
+type ParsedPolicyEvaluation struct {
+	Policy     string                       // the EndorsementPolicy, e.g. OR('Org1MSP.peer', 'Org2MSP.peer')
+	Satisfied  bool                         // whether the endorsements satisfy the policy
+	RoleMatch  string                       // RoleMatchVerified or RoleMatchUnverified
+	Principals []*ParsedPrincipalEvaluation // the identities of the policy
+}
+
+// the values of ParsedPolicyEvaluation.RoleMatch
+const (
+	// the endorsers were classified and validated against the MSPs of the Decoder, like a Fabric peer does
+	RoleMatchVerified = "verified"
+	// without MSPs, the roles were taken from the organizational units of the certificates alone, so a Satisfied
+	// policy only tells what the endorsers claim to be
+	RoleMatchUnverified = "unverified role match"
+)
+
+type ParsedPrincipalEvaluation struct {
+	Principal    string // e.g. Org1MSP.peer
+	Satisfied    bool   // whether one of the endorsements satisfies the principal
+	Endorsements []int  // indexes of the Endorsements that satisfy the principal
+}
+
+func (d *Decoder) evaluatesEndorsementPolicy() bool {
+	return d != nil && d.EndorsementPolicy != nil
+}
+
+// policyIdentity is an endorser as far as principals are concerned
+type policyIdentity struct {
+	mspid               string
+	idBytes             []byte
+	organizationalUnits []string
+	verified            bool   // whether role and chainValid come from the MSPs of the Decoder
+	role                string // the role classifyIdentity gives the endorser
+	chainValid          bool   // whether the endorser chains to the CAs of its MSP
+}
+
+func (d *Decoder) newPolicyIdentity(endorser []byte) (*policyIdentity, error) {
+	serializedIdentity := &msp.SerializedIdentity{}
+	err := serializedIdentity.XXX_Unmarshal(endorser)
+	if err != nil {
+		return nil, err
+	}
+	identity := &policyIdentity{mspid: serializedIdentity.GetMspid(), idBytes: serializedIdentity.GetIdBytes()}
+
+	if d.validatesIdentities() {
+		identity.verified = true
+		identity.role, _ = d.classifyIdentity(serializedIdentity)
+		identity.chainValid = d.validateIdentity(serializedIdentity).ChainValid
+	}
+
+	if identityKind(serializedIdentity.GetIdBytes()) != IdentityX509 {
+		return identity, nil
+	}
+	cert, err := parseCertificate(serializedIdentity.GetIdBytes())
+	if err != nil {
+		return nil, err
+	}
+	identity.organizationalUnits = cert.Subject.OrganizationalUnit
+	return identity, nil
+}
+
+func (pi *policyIdentity) hasOrganizationalUnit(organizationalUnit string) bool {
+	for _, ou := range pi.organizationalUnits {
+		if ou == organizationalUnit {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfies reports whether the identity satisfies principal. When the identity is verified, a role or an
+// organizational unit needs the X.509 certificate of the endorser to chain to the CAs of the MSP of the principal,
+// and the role is the one of classifyIdentity, from the admin certificates or the NodeOUs. Otherwise the roles other
+// than member are taken from the organizational units of the certificate, with the default identifiers of Fabric
+// node OUs: admin, client, peer and orderer.
+func (pi *policyIdentity) satisfies(principal *msp.MSPPrincipal) bool {
+	switch principal.GetPrincipalClassification() {
+	case msp.MSPPrincipal_ROLE:
+		mspRole := &msp.MSPRole{}
+		if mspRole.XXX_Unmarshal(principal.GetPrincipal()) != nil || mspRole.GetMspIdentifier() != pi.mspid {
+			return false
+		}
+		if pi.verified {
+			if !pi.chainValid || pi.role == "" {
+				return false
+			}
+			return mspRole.GetRole() == msp.MSPRole_MEMBER || pi.role == strings.ToLower(mspRole.GetRole().String())
+		}
+		if mspRole.GetRole() == msp.MSPRole_MEMBER {
+			return true
+		}
+		return pi.hasOrganizationalUnit(strings.ToLower(mspRole.GetRole().String()))
+	case msp.MSPPrincipal_ORGANIZATION_UNIT:
+		organizationUnit := &msp.OrganizationUnit{}
+		if organizationUnit.XXX_Unmarshal(principal.GetPrincipal()) != nil || organizationUnit.GetMspIdentifier() != pi.mspid {
+			return false
+		}
+		if pi.verified && !pi.chainValid {
+			return false
+		}
+		return pi.hasOrganizationalUnit(organizationUnit.GetOrganizationalUnitIdentifier())
+	case msp.MSPPrincipal_IDENTITY:
+		serializedIdentity := &msp.SerializedIdentity{}
+		if serializedIdentity.XXX_Unmarshal(principal.GetPrincipal()) != nil {
+			return false
+		}
+		return serializedIdentity.GetMspid() == pi.mspid && bytes.Equal(serializedIdentity.GetIdBytes(), pi.idBytes)
+	}
+	return false
+}
+
+// evaluateEndorsementPolicy evaluates the EndorsementPolicy of the Decoder against the endorsements of a chaincode
+// action. The signatures the Decoder already verified into decodedEndorsements are not verified again.
+func (d *Decoder) evaluateEndorsementPolicy(endorsements []*peer.Endorsement, decodedEndorsements []*ParsedEndorsement, proposalResponsePayload []byte) *ParsedPolicyEvaluation {
+	policy := d.EndorsementPolicy
+	evaluation := &ParsedPolicyEvaluation{Policy: policyString(policy.GetRule(), policy.GetIdentities()), RoleMatch: RoleMatchUnverified}
+	if d.validatesIdentities() {
+		evaluation.RoleMatch = RoleMatchVerified
+	}
+
+	// the endorsers that count, each of them once
+	identities := []*policyIdentity{}
+	indexes := []int{}
+	seen := map[string]bool{}
+	for i, endorsement := range endorsements {
+		var signatureValid *bool
+		if i < len(decodedEndorsements) {
+			signatureValid = decodedEndorsements[i].SignatureValid
+		}
+		if signatureValid == nil {
+			signatureValid, _ = verifyEndorsementSignature(endorsement, proposalResponsePayload)
+		}
+		if !*signatureValid {
+			continue
+		}
+		if seen[string(endorsement.GetEndorser())] {
+			continue
+		}
+		identity, err := d.newPolicyIdentity(endorsement.GetEndorser())
+		if err != nil {
+			continue
+		}
+		seen[string(endorsement.GetEndorser())] = true
+		identities = append(identities, identity)
+		indexes = append(indexes, i)
+	}
+
+	for _, principal := range policy.GetIdentities() {
+		principalEvaluation := &ParsedPrincipalEvaluation{Principal: principalString(principal), Endorsements: []int{}}
+		for i, identity := range identities {
+			if identity.satisfies(principal) {
+				principalEvaluation.Endorsements = append(principalEvaluation.Endorsements, indexes[i])
+			}
+		}
+		principalEvaluation.Satisfied = len(principalEvaluation.Endorsements) > 0
+		evaluation.Principals = append(evaluation.Principals, principalEvaluation)
+	}
+
+	evaluation.Satisfied = evaluateRule(policy.GetRule(), policy.GetIdentities(), identities, make([]bool, len(identities)))
+	return evaluation
+}
+
+// evaluateRule evaluates rule the way the cauthdsl of a Fabric peer does, an identity that satisfied a
+// principal is used up for the other principals
+func evaluateRule(rule *common.SignaturePolicy, principals []*msp.MSPPrincipal, identities []*policyIdentity, used []bool) bool {
+	switch rule.GetType().(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if rule.GetSignedBy() < 0 || int(rule.GetSignedBy()) >= len(principals) {
+			return false
+		}
+		for i, identity := range identities {
+			if !used[i] && identity.satisfies(principals[rule.GetSignedBy()]) {
+				used[i] = true
+				return true
+			}
+		}
+		return false
+	case *common.SignaturePolicy_NOutOf_:
+		verified := int32(0)
+		ruleUsed := make([]bool, len(used))
+		for _, subRule := range rule.GetNOutOf().GetRules() {
+			copy(ruleUsed, used)
+			if evaluateRule(subRule, principals, identities, ruleUsed) {
+				verified++
+				copy(used, ruleUsed)
+			}
+		}
+		return verified >= rule.GetNOutOf().GetN()
+	}
+	return false
+}
+
+// policyString writes rule in the syntax of ParsePolicy
+func policyString(rule *common.SignaturePolicy, principals []*msp.MSPPrincipal) string {
+	switch rule.GetType().(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if rule.GetSignedBy() < 0 || int(rule.GetSignedBy()) >= len(principals) {
+			return fmt.Sprintf("'unknown identity %d'", rule.GetSignedBy())
+		}
+		return "'" + principalString(principals[rule.GetSignedBy()]) + "'"
+	case *common.SignaturePolicy_NOutOf_:
+		rules := []string{}
+		for _, subRule := range rule.GetNOutOf().GetRules() {
+			rules = append(rules, policyString(subRule, principals))
+		}
+		// a single rule is an OR(...) as ParsePolicy reads it, not an AND(...)
+		switch int(rule.GetNOutOf().GetN()) {
+		case 1:
+			return "OR(" + strings.Join(rules, ", ") + ")"
+		case len(rules):
+			return "AND(" + strings.Join(rules, ", ") + ")"
+		}
+		return fmt.Sprintf("OutOf(%d, %s)", rule.GetNOutOf().GetN(), strings.Join(rules, ", "))
+	}
+	return ""
+}
+
+// principalString names principal like ParsePolicy does for roles, e.g. Org1MSP.peer
+func principalString(principal *msp.MSPPrincipal) string {
+	switch principal.GetPrincipalClassification() {
+	case msp.MSPPrincipal_ROLE:
+		mspRole := &msp.MSPRole{}
+		if mspRole.XXX_Unmarshal(principal.GetPrincipal()) == nil {
+			return mspRole.GetMspIdentifier() + "." + strings.ToLower(mspRole.GetRole().String())
+		}
+	case msp.MSPPrincipal_ORGANIZATION_UNIT:
+		organizationUnit := &msp.OrganizationUnit{}
+		if organizationUnit.XXX_Unmarshal(principal.GetPrincipal()) == nil {
+			return organizationUnit.GetMspIdentifier() + ".OU=" + organizationUnit.GetOrganizationalUnitIdentifier()
+		}
+	case msp.MSPPrincipal_IDENTITY:
+		serializedIdentity := &msp.SerializedIdentity{}
+		if serializedIdentity.XXX_Unmarshal(principal.GetPrincipal()) == nil {
+			return serializedIdentity.GetMspid() + ".identity"
+		}
+	}
+	return principal.GetPrincipalClassification().String()
+}
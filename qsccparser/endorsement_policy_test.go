@@ -0,0 +1,160 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		expression string
+		policy     string // as policyString writes it back, none for an error
+		identities int
+	}{
+		{"OR('Org1MSP.peer')", "OR('Org1MSP.peer')", 1},
+		{"AND('Org1MSP.peer')", "OR('Org1MSP.peer')", 1},
+		{"OR('Org1MSP.peer', 'Org2MSP.peer')", "OR('Org1MSP.peer', 'Org2MSP.peer')", 2},
+		{"and(\"Org1MSP.peer\",\"Org2MSP.admin\")", "AND('Org1MSP.peer', 'Org2MSP.admin')", 2},
+		{"OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')", "OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')", 3},
+		{"OutOf(1, 'Org1MSP.peer', 'Org2MSP.peer')", "OR('Org1MSP.peer', 'Org2MSP.peer')", 2},
+		{"OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer')", "AND('Org1MSP.peer', 'Org2MSP.peer')", 2},
+		{"AND('Org1MSP.member', OR('Org2MSP.client', 'Org3MSP.orderer'))", "AND('Org1MSP.member', OR('Org2MSP.client', 'Org3MSP.orderer'))", 3},
+		{"AND('Org1MSP.peer', 'Org1MSP.peer')", "AND('Org1MSP.peer', 'Org1MSP.peer')", 1},
+		{"'Org1MSP.peer'", "'Org1MSP.peer'", 1},
+		{"OR('Org1MSP.peer'", "", 0},
+		{"OR('Org1MSP.owner')", "", 0},
+		{"OutOf(two, 'Org1MSP.peer')", "", 0},
+		{"OutOf(-1, 'Org1MSP.peer')", "", 0},
+		{"OutOf(0, 'Org1MSP.peer', 'Org2MSP.peer')", "", 0},
+		{"OutOf(5, 'Org1MSP.peer')", "", 0},
+		{"OutOf(3, 'Org1MSP.peer', 'Org2MSP.peer')", "", 0},
+		{"OR('Org1MSP.peer') AND", "", 0},
+		{"", "", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			policy, err := ParsePolicy(test.expression)
+			if test.policy == "" {
+				if err == nil {
+					t.Errorf("ParsePolicy() = %s, want an error", policyString(policy.GetRule(), policy.GetIdentities()))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := policyString(policy.GetRule(), policy.GetIdentities()); got != test.policy {
+				t.Errorf("policyString() = %s, want %s", got, test.policy)
+			}
+			if len(policy.GetIdentities()) != test.identities {
+				t.Errorf("ParsePolicy() has %d identities, want %d", len(policy.GetIdentities()), test.identities)
+			}
+		})
+	}
+}
+
+func TestEvaluateEndorsementPolicy(t *testing.T) {
+	org1 := newTestCA(t, "org1")
+	org2 := newTestCA(t, "org2")
+	org1Peer0 := org1.signer(t, "Org1MSP", "peer0", "peer")
+	org1Peer1 := org1.signer(t, "Org1MSP", "peer1", "peer")
+	org1Client := org1.signer(t, "Org1MSP", "user1", "client")
+	org2Peer0 := org2.signer(t, "Org2MSP", "peer0", "peer")
+	// a peer of org2 that claims to be of Org1MSP
+	fakePeer := org2.signer(t, "Org1MSP", "peer9", "peer")
+	role := testMarshal(t, &msp.MSPRole{MspIdentifier: "Org1MSP", Role: msp.MSPRole_PEER})
+	idemixPeer := &testSigner{identity: testIdentity(t, "Org1MSP", testMarshal(t, &msp.SerializedIdemixIdentity{NymX: []byte{1}, NymY: []byte{2}, Role: role})), key: newTestKey(t)}
+
+	nodeOUs := func(ca *testCA) *MSP {
+		return &MSP{RootCerts: []*x509.Certificate{ca.cert}, NodeOUs: &NodeOUs{
+			Enable:             true,
+			ClientOUIdentifier: &OUIdentifier{OrganizationalUnitIdentifier: "client"},
+			PeerOUIdentifier:   &OUIdentifier{OrganizationalUnitIdentifier: "peer"},
+		}}
+	}
+	withNodeOUs := map[string]*MSP{"Org1MSP": nodeOUs(org1), "Org2MSP": nodeOUs(org2)}
+	withoutNodeOUs := map[string]*MSP{"Org1MSP": org1.msp(), "Org2MSP": org2.msp()}
+	tamper := func(i int) func(endorsements []*peer.Endorsement) {
+		return func(endorsements []*peer.Endorsement) {
+			endorsements[i].Signature = endorsements[(i+1)%len(endorsements)].Signature
+		}
+	}
+
+	tests := []struct {
+		name         string
+		policy       string
+		endorsers    []*testSigner
+		tamper       func(endorsements []*peer.Endorsement)
+		msps         map[string]*MSP
+		satisfied    bool
+		endorsements [][]int // the Endorsements of each principal
+	}{
+		{"OR with one of them", "OR('Org1MSP.peer', 'Org2MSP.peer')", []*testSigner{org2Peer0}, nil, nil, true, [][]int{{}, {0}}},
+		{"OR with none of them", "OR('Org1MSP.peer', 'Org2MSP.peer')", []*testSigner{org1Client}, nil, nil, false, [][]int{{}, {}}},
+		{"AND with one of them", "AND('Org1MSP.peer', 'Org2MSP.peer')", []*testSigner{org1Peer0}, nil, nil, false, [][]int{{0}, {}}},
+		{"AND with both", "AND('Org1MSP.peer', 'Org2MSP.peer')", []*testSigner{org1Peer0, org2Peer0}, nil, nil, true, [][]int{{0}, {1}}},
+		{"AND with a tampered signature", "AND('Org1MSP.peer', 'Org2MSP.peer')", []*testSigner{org1Peer0, org2Peer0}, tamper(1), nil, false, [][]int{{0}, {}}},
+		{"OutOf 2 of 3 with 2", "OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')", []*testSigner{org1Peer0, org2Peer0}, nil, nil, true, [][]int{{0}, {1}, {}}},
+		{"OutOf 2 of 3 with 1", "OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')", []*testSigner{org2Peer0}, nil, nil, false, [][]int{{}, {0}, {}}},
+		{"the same principal twice by two endorsers", "AND('Org1MSP.peer', 'Org1MSP.peer')", []*testSigner{org1Peer0, org1Peer1}, nil, nil, true, [][]int{{0, 1}}},
+		{"the same principal twice by the same endorser", "AND('Org1MSP.peer', 'Org1MSP.peer')", []*testSigner{org1Peer0, org1Peer0}, nil, nil, false, [][]int{{0}}},
+		{"member and peer by the same endorser", "AND('Org1MSP.member', 'Org1MSP.peer')", []*testSigner{org1Peer0}, nil, nil, false, [][]int{{0}, {0}}},
+		{"unverified wrong MSP", "OR('Org1MSP.peer')", []*testSigner{fakePeer}, nil, nil, true, [][]int{{0}}},
+		{"verified wrong MSP", "OR('Org1MSP.peer')", []*testSigner{fakePeer}, nil, withNodeOUs, false, [][]int{{}}},
+		{"verified wrong MSP member", "OR('Org1MSP.member')", []*testSigner{fakePeer}, nil, withNodeOUs, false, [][]int{{}}},
+		{"verified peer", "OR('Org1MSP.peer')", []*testSigner{org1Peer0}, nil, withNodeOUs, true, [][]int{{0}}},
+		{"verified client", "OR('Org1MSP.peer', 'Org1MSP.client')", []*testSigner{org1Client}, nil, withNodeOUs, true, [][]int{{}, {0}}},
+		{"verified without NodeOUs", "OR('Org1MSP.peer')", []*testSigner{org1Peer0}, nil, withoutNodeOUs, false, [][]int{{}}},
+		{"verified member without NodeOUs", "OR('Org1MSP.member')", []*testSigner{org1Peer0}, nil, withoutNodeOUs, true, [][]int{{0}}},
+		// the signatures of Idemix identities are not verified, so they never count
+		{"unverified Idemix", "OR('Org1MSP.member')", []*testSigner{idemixPeer}, nil, nil, false, [][]int{{}}},
+		{"verified Idemix", "OR('Org1MSP.peer')", []*testSigner{idemixPeer}, nil, withNodeOUs, false, [][]int{{}}},
+	}
+	for _, test := range tests {
+		// the signatures count the same whether the Decoder verifies them into the Endorsements or not
+		for _, verifySignatures := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/VerifySignatures=%v", test.name, verifySignatures), func(t *testing.T) {
+				policy, err := ParsePolicy(test.policy)
+				if err != nil {
+					t.Fatal(err)
+				}
+				transaction := &testTransaction{creator: org1Client, endorsers: test.endorsers, tamper: test.tamper}
+				decoder := &Decoder{EndorsementPolicy: policy, VerifySignatures: verifySignatures, MSPs: test.msps}
+				processedTransaction, err := decoder.DecodeTransaction(transaction.marshal(t))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				evaluation := endorsedAction(t, processedTransaction).EndorsementPolicy
+				if evaluation == nil {
+					t.Fatal("EndorsementPolicy is not evaluated")
+				}
+				if evaluation.Satisfied != test.satisfied {
+					t.Errorf("Satisfied = %v, want %v", evaluation.Satisfied, test.satisfied)
+				}
+				roleMatch := RoleMatchUnverified
+				if test.msps != nil {
+					roleMatch = RoleMatchVerified
+				}
+				if evaluation.RoleMatch != roleMatch {
+					t.Errorf("RoleMatch = %q, want %q", evaluation.RoleMatch, roleMatch)
+				}
+				endorsements := [][]int{}
+				for _, principal := range evaluation.Principals {
+					endorsements = append(endorsements, principal.Endorsements)
+					if principal.Satisfied != (len(principal.Endorsements) > 0) {
+						t.Errorf("%s Satisfied = %v with the endorsements %v", principal.Principal, principal.Satisfied, principal.Endorsements)
+					}
+				}
+				if !reflect.DeepEqual(endorsements, test.endorsements) {
+					t.Errorf("the endorsements of the principals = %v, want %v", endorsements, test.endorsements)
+				}
+			})
+		}
+	}
+}
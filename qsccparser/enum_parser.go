@@ -0,0 +1,59 @@
+package qsccparser
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// ParsedEnum carries both the numeric value of an enum and its symbolic name, e.g.
+// {"Code": 3, "Name": "ENDORSER_TRANSACTION"}. Values without a known name get a
+// generated one, so Name is never empty.
+type ParsedEnum struct {
+	Code int32
+	Name string
+}
+
+// chaincode response statuses, see fabric-chaincode-go/shim
+var responseStatusName = map[int32]string{
+	200: "OK",
+	400: "ERRORTHRESHOLD",
+	500: "ERROR",
+}
+
+func newParsedEnum(code int32, names map[int32]string, unknownPrefix string) ParsedEnum {
+	if name, ok := names[code]; ok {
+		return ParsedEnum{Code: code, Name: name}
+	}
+	return ParsedEnum{Code: code, Name: fmt.Sprintf("%s_%d", unknownPrefix, code)}
+}
+
+// headerTypeEnum names a common.HeaderType, including the ones fabric-protos-go no longer defines
+func headerTypeEnum(headerType int32) ParsedEnum {
+	if common.HeaderType(headerType) == HeaderType_PEER_ADMIN_OPERATION {
+		return ParsedEnum{Code: headerType, Name: "PEER_ADMIN_OPERATION"}
+	}
+	return newParsedEnum(headerType, common.HeaderType_name, "UNKNOWN_HEADER_TYPE")
+}
+
+// validationCodeEnum names a peer.TxValidationCode
+func validationCodeEnum(validationCode int32) ParsedEnum {
+	return newParsedEnum(validationCode, peer.TxValidationCode_name, "UNKNOWN_VALIDATION_CODE")
+}
+
+// chaincodeTypeEnum names a peer.ChaincodeSpec_Type
+func chaincodeTypeEnum(chaincodeType peer.ChaincodeSpec_Type) ParsedEnum {
+	return newParsedEnum(int32(chaincodeType), peer.ChaincodeSpec_Type_name, "UNKNOWN_CHAINCODE_TYPE")
+}
+
+// dataModelEnum names a rwset.TxReadWriteSet_DataModel
+func dataModelEnum(dataModel rwset.TxReadWriteSet_DataModel) ParsedEnum {
+	return newParsedEnum(int32(dataModel), rwset.TxReadWriteSet_DataModel_name, "UNKNOWN_DATA_MODEL")
+}
+
+// responseStatusEnum names the status of a peer.Response
+func responseStatusEnum(status int32) ParsedEnum {
+	return newParsedEnum(status, responseStatusName, "STATUS")
+}
@@ -0,0 +1,66 @@
+package qsccparser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestParsedEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		enum ParsedEnum
+		want ParsedEnum
+	}{
+		{"header type", headerTypeEnum(int32(common.HeaderType_ENDORSER_TRANSACTION)), ParsedEnum{3, "ENDORSER_TRANSACTION"}},
+		{"header type of Fabric 1.4", headerTypeEnum(int32(HeaderType_PEER_ADMIN_OPERATION)), ParsedEnum{8, "PEER_ADMIN_OPERATION"}},
+		{"unknown header type", headerTypeEnum(42), ParsedEnum{42, "UNKNOWN_HEADER_TYPE_42"}},
+		{"validation code", validationCodeEnum(int32(peer.TxValidationCode_MVCC_READ_CONFLICT)), ParsedEnum{11, "MVCC_READ_CONFLICT"}},
+		{"unknown validation code", validationCodeEnum(200), ParsedEnum{200, "UNKNOWN_VALIDATION_CODE_200"}},
+		{"chaincode type", chaincodeTypeEnum(peer.ChaincodeSpec_GOLANG), ParsedEnum{1, "GOLANG"}},
+		{"unknown chaincode type", chaincodeTypeEnum(9), ParsedEnum{9, "UNKNOWN_CHAINCODE_TYPE_9"}},
+		{"data model", dataModelEnum(rwset.TxReadWriteSet_KV), ParsedEnum{0, "KV"}},
+		{"unknown data model", dataModelEnum(5), ParsedEnum{5, "UNKNOWN_DATA_MODEL_5"}},
+		{"response status", responseStatusEnum(500), ParsedEnum{500, "ERROR"}},
+		// chaincodes may answer with any status
+		{"other response status", responseStatusEnum(404), ParsedEnum{404, "STATUS_404"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.enum != test.want {
+				t.Errorf("enum = %+v, want %+v", test.enum, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionEnums(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	user1 := ca.signer(t, "Org1MSP", "user1", "client")
+	envelope := (&testTransaction{creator: user1, endorsers: []*testSigner{user1}}).envelope(t)
+	data := testMarshal(t, &peer.ProcessedTransaction{TransactionEnvelope: envelope, ValidationCode: int32(peer.TxValidationCode_MVCC_READ_CONFLICT)})
+
+	processedTransaction, err := DecodeTransaction(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := json.Marshal(processedTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the enums are objects of both their code and their name in the JSON output
+	for _, want := range []string{
+		`"ValidationCode":{"Code":11,"Name":"MVCC_READ_CONFLICT"}`,
+		`"Type":{"Code":3,"Name":"ENDORSER_TRANSACTION"}`,
+		`"Type":{"Code":1,"Name":"GOLANG"}`,
+		`"Status":{"Code":200,"Name":"OK"}`,
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("json.Marshal() has no %s", want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package qsccparser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/runtime/protoiface"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// DecodeError is returned when a part of a qscc response cannot be decoded.
+// Err is the cause reported by proto or x509, reachable through errors.Is and errors.As.
+type DecodeError struct {
+	Path    string // location of the part in the Parsed* tree, e.g. TransactionEnvelope.Payload.Data.Actions[0].Payload, empty for the response itself
+	Message string // what the bytes were decoded as, the full proto message name, e.g. protos.ChaincodeActionPayload, or x509.Certificate
+	Length  int    // length of the bytes that could not be decoded
+	Offset  int    // of the bytes in the response, -1 when they are not found verbatim in it or found more than once
+	Err     error
+
+	raw []byte
+}
+
+func (e *DecodeError) Error() string {
+	size := fmt.Sprintf("%d bytes", e.Length)
+	if e.Offset >= 0 {
+		size = fmt.Sprintf("%d bytes at offset %d", e.Length, e.Offset)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("decode %s (%s): %v", e.Message, size, e.Err)
+	}
+	return fmt.Sprintf("decode %s (%s) at %s: %v", e.Message, size, e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeErrors collects every DecodeError of a response that was decoded past its failures
+type DecodeErrors []*DecodeError
+
+func (e DecodeErrors) Error() string {
+	messages := []string{}
+	for _, decodeError := range e {
+		messages = append(messages, decodeError.Error())
+	}
+	return fmt.Sprintf("%d decode errors: %s", len(e), strings.Join(messages, "; "))
+}
+
+func (e DecodeErrors) Unwrap() []error {
+	errs := []error{}
+	for _, decodeError := range e {
+		errs = append(errs, decodeError)
+	}
+	return errs
+}
+
+func decodeError(path string, message string, data []byte, err error) error {
+	return &DecodeError{Path: path, Message: message, Length: len(data), Offset: -1, Err: err, raw: data}
+}
+
+// locateError sets the Offset of a *DecodeError from the response data, other errors are left as they are
+func locateError(data []byte, err error) {
+	if decodeErr, ok := err.(*DecodeError); ok {
+		decodeErr.Offset = offsetOf(data, decodeErr.raw)
+	}
+}
+
+// offsetOf finds part in data. Protobuf keeps the bytes fields, and so the nested messages, verbatim in the message
+// they belong to, so the bytes of a part that failed are found in the response unless they were decoded from
+// something else, e.g. a certificate extension. Bytes found more than once cannot be told apart.
+func offsetOf(data []byte, part []byte) int {
+	if len(part) == 0 {
+		return -1
+	}
+	offset := bytes.Index(data, part)
+	if offset < 0 || bytes.Contains(data[offset+1:], part) {
+		return -1
+	}
+	return offset
+}
+
+// unmarshalError reports data that could not be unmarshaled into message
+func unmarshalError(path string, message protoiface.MessageV1, data []byte, err error) error {
+	return decodeError(path, string(protoimpl.X.MessageDescriptorOf(message).FullName()), data, err)
+}
+
+// prefixPath prepends the field a child decoder was called for to the Path of a *DecodeError,
+// other errors are returned as they are
+func prefixPath(field string, err error) error {
+	if decodeErr, ok := err.(*DecodeError); ok {
+		decodeErr.Path = joinPath(field, decodeErr.Path)
+	}
+	return err
+}
+
+func joinPath(field string, path string) string {
+	switch {
+	case field == "":
+		return path
+	case path == "":
+		return field
+	case strings.HasPrefix(path, "["):
+		return field + path
+	default:
+		return field + "." + path
+	}
+}
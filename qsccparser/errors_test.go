@@ -0,0 +1,143 @@
+package qsccparser
+
+import (
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestOffsetOf(t *testing.T) {
+	data := []byte("header|payload|signature|payload2")
+
+	tests := []struct {
+		name   string
+		part   []byte
+		offset int
+	}{
+		{"found once", []byte("signature"), 15},
+		{"at the start", []byte("header"), 0},
+		{"at the end", []byte("payload2"), 25},
+		{"found twice", []byte("payload"), -1},
+		{"overlapping", []byte("a"), -1},
+		{"not found", []byte("trailer"), -1},
+		{"empty", []byte{}, -1},
+		{"the whole data", data, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if offset := offsetOf(data, test.part); offset != test.offset {
+				t.Errorf("offsetOf() = %d, want %d", offset, test.offset)
+			}
+		})
+	}
+}
+
+func TestLocateError(t *testing.T) {
+	data := []byte("header|payload|signature")
+
+	decodeErr := decodeError("Payload", "common.Payload", []byte("payload"), errors.New("truncated")).(*DecodeError)
+	if decodeErr.Offset != -1 {
+		t.Fatalf("decodeError() Offset = %d, want -1 until it is located", decodeErr.Offset)
+	}
+	locateError(data, decodeErr)
+	if decodeErr.Offset != 7 {
+		t.Errorf("Offset = %d, want 7", decodeErr.Offset)
+	}
+	if !strings.Contains(decodeErr.Error(), "(7 bytes at offset 7) at Payload") {
+		t.Errorf("Error() = %q, want the length and the offset", decodeErr.Error())
+	}
+
+	// other errors are left as they are
+	err := errors.New("not a DecodeError")
+	locateError(data, err)
+	if err.Error() != "not a DecodeError" {
+		t.Errorf("locateError() changed %v", err)
+	}
+}
+
+func TestDecodeTransactionErrorOffset(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	junk := &testSigner{identity: testIdentity(t, "Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("junk")})), key: newTestKey(t)}
+	chaincodeProposalPayload := testMarshal(t, &peer.ChaincodeProposalPayload{TransientMap: map[string][]byte{"asset": []byte("a transient value")}})
+	chaincodeActionPayload := testMarshal(t, &peer.ChaincodeActionPayload{ChaincodeProposalPayload: chaincodeProposalPayload})
+	// cut within the ChaincodeProposalPayload, so that the ChaincodeActionPayload itself cannot be unmarshaled
+	truncated := chaincodeActionPayload[:len(chaincodeActionPayload)-3]
+
+	tests := []struct {
+		name        string
+		transaction *testTransaction
+		raw         []byte // the bytes the first DecodeError is located from, none when they are not unique
+	}{
+		{"truncated nested payload", &testTransaction{creator: creator, chaincodeActionPayload: truncated}, truncated},
+		// the creator certificate is in the signature header and again in the action header
+		{"duplicated segment", &testTransaction{creator: junk}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := test.transaction.marshal(t)
+			for _, decoder := range []*Decoder{{}, {Lenient: true}} {
+				_, err := decoder.DecodeTransaction(data)
+				var decodeErr *DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("DecodeTransaction() = %v, want a DecodeError", err)
+				}
+				if test.raw == nil {
+					if decodeErr.Offset != -1 || strings.Contains(decodeErr.Error(), "offset") {
+						t.Errorf("Offset = %d, want -1 for bytes found more than once: %v", decodeErr.Offset, decodeErr)
+					}
+					continue
+				}
+				if decodeErr.Length != len(test.raw) || decodeErr.Offset < 0 || !bytes.Equal(data[decodeErr.Offset:decodeErr.Offset+decodeErr.Length], test.raw) {
+					t.Errorf("Offset = %d, Length = %d, want the location of the %d bytes in the response", decodeErr.Offset, decodeErr.Length, len(test.raw))
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionErrorPath(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	malformed := []byte{0x0a, 0xff}
+	channelHeader := testMarshal(t, &common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION), ChannelId: "mychannel"})
+	testPayload := func(payload *common.Payload) []byte {
+		return testMarshal(t, &peer.ProcessedTransaction{TransactionEnvelope: &common.Envelope{Payload: testMarshal(t, payload)}})
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		path    string
+		message string
+	}{
+		{"response", malformed, "", "protos.ProcessedTransaction"},
+		{"payload", testMarshal(t, &peer.ProcessedTransaction{TransactionEnvelope: &common.Envelope{Payload: malformed}}), "TransactionEnvelope.Payload", "common.Payload"},
+		{"channel header", testPayload(&common.Payload{Header: &common.Header{ChannelHeader: malformed}}), "TransactionEnvelope.Payload.Header.ChannelHeader", "common.ChannelHeader"},
+		{"signature header", testPayload(&common.Payload{Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: malformed}}), "TransactionEnvelope.Payload.Header.SignatureHeader", "common.SignatureHeader"},
+		{"transaction", testPayload(&common.Payload{Header: &common.Header{ChannelHeader: channelHeader}, Data: malformed}), "TransactionEnvelope.Payload.Data", "protos.Transaction"},
+		{"chaincode action payload", (&testTransaction{creator: creator, chaincodeActionPayload: malformed}).marshal(t), "TransactionEnvelope.Payload.Data.Actions[0].Payload", "protos.ChaincodeActionPayload"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// rather than an empty result, the first part that cannot be unmarshaled is returned with its path
+			processedTransaction, err := DecodeTransaction(test.data)
+			var decodeErr *DecodeError
+			if processedTransaction != nil || !errors.As(err, &decodeErr) {
+				t.Fatalf("DecodeTransaction() = %v, %v, want a DecodeError", processedTransaction, err)
+			}
+			if decodeErr.Path != test.path || decodeErr.Message != test.message || decodeErr.Length != len(malformed) {
+				t.Errorf("DecodeError = %+v, want the %d bytes of the %s at %q", decodeErr, len(malformed), test.message, test.path)
+			}
+			// the error of the protobuf unmarshaling is kept
+			if decodeErr.Err == nil || errors.Unwrap(decodeErr) != decodeErr.Err {
+				t.Errorf("Unwrap() = %v, want the unmarshal error", errors.Unwrap(decodeErr))
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package qsccparser_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/WK-ING/hlf-qscc-parser/qsccparser"
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+func ExampleDecodeChainInfo() {
+	// the response of qscc GetChainInfo
+	data, err := (&common.BlockchainInfo{Height: 10, CurrentBlockHash: []byte("current"), PreviousBlockHash: []byte("previous")}).XXX_Marshal(nil, false)
+	if err != nil {
+		panic(err)
+	}
+
+	blockchainInfo, err := qsccparser.DecodeChainInfo(data)
+	if err != nil {
+		panic(err)
+	}
+	output, err := json.Marshal(blockchainInfo)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(output))
+	// Output: {"Height":10,"CurrentBlockHash":"Y3VycmVudA==","PreviousBlockHash":"cHJldmlvdXM=","BootstrappingSnapshotInfo":null}
+}
+
+func ExampleDecodeBlock() {
+	// the response of qscc GetBlockByNumber
+	data, err := (&common.Block{Header: &common.BlockHeader{Number: 7, DataHash: []byte("data hash")}, Data: &common.BlockData{}}).XXX_Marshal(nil, false)
+	if err != nil {
+		panic(err)
+	}
+
+	block, err := qsccparser.DecodeBlock(data)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(block.Header.Number, string(block.Header.DataHash.Bytes), len(block.Data.Data))
+	// Output: 7 data hash 0
+}
+
+func ExampleDecoder() {
+	decoder := &qsccparser.Decoder{Lenient: true, BytesEncoding: qsccparser.BytesUTF8}
+
+	// a block whose second transaction is cut short
+	data, err := (&common.Block{Header: &common.BlockHeader{Number: 7}, Data: &common.BlockData{Data: [][]byte{{}, {0x0a, 0xff}}}}).XXX_Marshal(nil, false)
+	if err != nil {
+		panic(err)
+	}
+
+	block, err := decoder.DecodeBlock(data)
+	var decodeErrors qsccparser.DecodeErrors
+	if errors.As(err, &decodeErrors) {
+		for _, decodeErr := range decodeErrors {
+			fmt.Println(decodeErr.Path, decodeErr.Message)
+		}
+	}
+	fmt.Println(block.Header.Number, len(block.Data.Data))
+	// Output:
+	// Data.Data[1] common.Envelope
+	// 7 2
+}
@@ -0,0 +1,70 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+)
+
+// fabricAttributesOID is the extension in which the Fabric CA writes the attributes of an enrollment certificate,
+// as JSON like {"attrs":{"hf.EnrollmentID":"user1","hf.Type":"client"}}
+var fabricAttributesOID = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// names of the well-known extensions, for ParsedExtension.Name
+var extensionName = map[string]string{
+	"1.2.3.4.5.6.7.8.1": "FabricAttributes",
+	"1.3.6.1.5.5.7.1.1": "AuthorityInfoAccess",
+	"2.5.29.14":         "SubjectKeyIdentifier",
+	"2.5.29.15":         "KeyUsage",
+	"2.5.29.17":         "SubjectAltName",
+	"2.5.29.19":         "BasicConstraints",
+	"2.5.29.30":         "NameConstraints",
+	"2.5.29.31":         "CRLDistributionPoints",
+	"2.5.29.32":         "CertificatePolicies",
+	"2.5.29.35":         "AuthorityKeyIdentifier",
+	"2.5.29.37":         "ExtKeyUsage",
+}
+
+var extKeyUsageName = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "Any",
+	x509.ExtKeyUsageServerAuth:                     "ServerAuth",
+	x509.ExtKeyUsageClientAuth:                     "ClientAuth",
+	x509.ExtKeyUsageCodeSigning:                    "CodeSigning",
+	x509.ExtKeyUsageEmailProtection:                "EmailProtection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "IPSECEndSystem",
+	x509.ExtKeyUsageIPSECTunnel:                    "IPSECTunnel",
+	x509.ExtKeyUsageIPSECUser:                      "IPSECUser",
+	x509.ExtKeyUsageTimeStamping:                   "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSPSigning",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "MicrosoftServerGatedCrypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "NetscapeServerGatedCrypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "MicrosoftCommercialCodeSigning",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "MicrosoftKernelCodeSigning",
+}
+
+// decodeFabricAttributes decodes the value of the fabricAttributesOID extension
+func decodeFabricAttributes(value []byte) (map[string]string, error) {
+	attributes := struct {
+		Attrs map[string]string `json:"attrs"`
+	}{}
+	err := json.Unmarshal(value, &attributes)
+	return attributes.Attrs, err
+}
+
+// extKeyUsages names the extended key usages of cert: the ones crypto/x509 does not know by their OID, the ones
+// extKeyUsageName misses by their number, e.g. ExtKeyUsage(14)
+func extKeyUsages(cert *x509.Certificate) []string {
+	usages := []string{}
+	for _, usage := range cert.ExtKeyUsage {
+		name, ok := extKeyUsageName[usage]
+		if !ok {
+			name = fmt.Sprintf("ExtKeyUsage(%d)", usage)
+		}
+		usages = append(usages, name)
+	}
+	for _, usage := range cert.UnknownExtKeyUsage {
+		usages = append(usages, usage.String())
+	}
+	return usages
+}
@@ -0,0 +1,130 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtKeyUsages(t *testing.T) {
+	tests := []struct {
+		name    string
+		known   []x509.ExtKeyUsage
+		unknown []asn1.ObjectIdentifier
+		usages  []string
+	}{
+		{"none", nil, nil, []string{}},
+		{"named", []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, nil, []string{"ServerAuth", "ClientAuth"}},
+		{"unknown to crypto/x509", nil, []asn1.ObjectIdentifier{{1, 2, 3, 4}}, []string{"1.2.3.4"}},
+		{"unknown to extKeyUsageName", []x509.ExtKeyUsage{x509.ExtKeyUsage(99)}, nil, []string{"ExtKeyUsage(99)"}},
+		{"all of them", []x509.ExtKeyUsage{x509.ExtKeyUsageAny, x509.ExtKeyUsage(99)}, []asn1.ObjectIdentifier{{1, 2, 3, 4}}, []string{"Any", "ExtKeyUsage(99)", "1.2.3.4"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			usages := extKeyUsages(&x509.Certificate{ExtKeyUsage: test.known, UnknownExtKeyUsage: test.unknown})
+			if !reflect.DeepEqual(usages, test.usages) {
+				t.Errorf("extKeyUsages() = %q, want %q", usages, test.usages)
+			}
+		})
+	}
+
+	// every extended key usage crypto/x509 parses has a name
+	for usage := x509.ExtKeyUsageAny; usage <= x509.ExtKeyUsageMicrosoftKernelCodeSigning; usage++ {
+		if extKeyUsageName[usage] == "" {
+			t.Errorf("extKeyUsageName has no name for %d", usage)
+		}
+	}
+}
+
+func TestDecodeFabricAttributes(t *testing.T) {
+	tests := []struct {
+		value      string
+		attributes map[string]string // none for an error
+	}{
+		{`{"attrs":{"hf.EnrollmentID":"user1","hf.Type":"client"}}`, map[string]string{"hf.EnrollmentID": "user1", "hf.Type": "client"}},
+		{`{"attrs":{}}`, map[string]string{}},
+		{`{}`, nil},
+		{`{attrs`, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			attributes, err := decodeFabricAttributes([]byte(test.value))
+			if (err != nil) != (test.value == `{attrs`) {
+				t.Errorf("decodeFabricAttributes() error = %v", err)
+			}
+			if !reflect.DeepEqual(attributes, test.attributes) {
+				t.Errorf("decodeFabricAttributes() = %v, want %v", attributes, test.attributes)
+			}
+		})
+	}
+}
+
+func TestDecodeCertificateExtensions(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	key := newTestKey(t)
+	uri, err := url.Parse("spiffe://org1.example.com/peer0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := createTestCertificate(t, &x509.Certificate{
+		SerialNumber:          newTestSerialNumber(),
+		Subject:               pkix.Name{CommonName: "peer0.org1.example.com", OrganizationalUnit: []string{"peer"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		UnknownExtKeyUsage:    []asn1.ObjectIdentifier{{1, 2, 3, 4}},
+		BasicConstraintsValid: true,
+		SubjectKeyId:          []byte{0xab, 0xcd},
+		DNSNames:              []string{"peer0.org1.example.com"},
+		EmailAddresses:        []string{"admin@org1.example.com"},
+		IPAddresses:           []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:                  []*url.URL{uri},
+		CRLDistributionPoints: []string{"http://crl.org1.example.com/ca.crl"},
+		ExtraExtensions:       []pkix.Extension{{Id: fabricAttributesOID, Value: []byte(`{"attrs":{"hf.EnrollmentID":"peer0","hf.Type":"peer"}}`)}},
+	}, ca.cert, &key.PublicKey, ca.key)
+
+	decodedIdBytes := &ParsedIdBytes{}
+	err = decodedIdBytes.DecodeIdBytes(nil, cert.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := map[string]string{"hf.EnrollmentID": "peer0", "hf.Type": "peer"}; !reflect.DeepEqual(decodedIdBytes.Attributes, want) {
+		t.Errorf("Attributes = %v, want %v", decodedIdBytes.Attributes, want)
+	}
+	if want := []string{"ServerAuth", "ClientAuth", "1.2.3.4"}; !reflect.DeepEqual(decodedIdBytes.ExtKeyUsage, want) {
+		t.Errorf("ExtKeyUsage = %q, want %q", decodedIdBytes.ExtKeyUsage, want)
+	}
+	if !reflect.DeepEqual(decodedIdBytes.DNSNames, []string{"peer0.org1.example.com"}) ||
+		!reflect.DeepEqual(decodedIdBytes.EmailAddresses, []string{"admin@org1.example.com"}) ||
+		!reflect.DeepEqual(decodedIdBytes.IPAddresses, []string{"10.0.0.1"}) ||
+		!reflect.DeepEqual(decodedIdBytes.URIs, []string{"spiffe://org1.example.com/peer0"}) {
+		t.Errorf("DNSNames = %q, EmailAddresses = %q, IPAddresses = %q, URIs = %q", decodedIdBytes.DNSNames, decodedIdBytes.EmailAddresses, decodedIdBytes.IPAddresses, decodedIdBytes.URIs)
+	}
+	if decodedIdBytes.SubjectKeyIdHex != "abcd" || decodedIdBytes.AuthorityKeyIdHex == "" {
+		t.Errorf("SubjectKeyIdHex = %q, AuthorityKeyIdHex = %q", decodedIdBytes.SubjectKeyIdHex, decodedIdBytes.AuthorityKeyIdHex)
+	}
+	if !reflect.DeepEqual(decodedIdBytes.CRLDistributionPoints, []string{"http://crl.org1.example.com/ca.crl"}) {
+		t.Errorf("CRLDistributionPoints = %q", decodedIdBytes.CRLDistributionPoints)
+	}
+
+	// every extension is kept, the well-known ones with their name
+	names := map[string]string{}
+	for _, extension := range decodedIdBytes.Extensions {
+		names[extension.Id] = extension.Name
+	}
+	for id, name := range map[string]string{"1.2.3.4.5.6.7.8.1": "FabricAttributes", "2.5.29.15": "KeyUsage", "2.5.29.17": "SubjectAltName", "2.5.29.31": "CRLDistributionPoints", "2.5.29.37": "ExtKeyUsage"} {
+		if names[id] != name {
+			t.Errorf("the extension %s has the Name %q, want %q", id, names[id], name)
+		}
+	}
+	if len(names) != len(cert.Extensions) {
+		t.Errorf("Extensions has %d entries, want %d", len(names), len(cert.Extensions))
+	}
+}
@@ -0,0 +1,308 @@
+package qsccparser
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testCA issues the certificates of the tests, with keys generated for each test
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+var testSerialNumber int64
+
+func newTestSerialNumber() *big.Int {
+	testSerialNumber++
+	return big.NewInt(testSerialNumber)
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// newTestCA creates a self-signed root CA of org
+func newTestCA(t *testing.T, org string) *testCA {
+	t.Helper()
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          newTestSerialNumber(),
+		Subject:               pkix.Name{CommonName: "ca." + org, Organization: []string{org}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	return &testCA{cert: createTestCertificate(t, template, template, &key.PublicKey, key), key: key}
+}
+
+// intermediate creates an intermediate CA issued by ca
+func (ca *testCA) intermediate(t *testing.T) *testCA {
+	t.Helper()
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          newTestSerialNumber(),
+		Subject:               pkix.Name{CommonName: "ica." + ca.cert.Subject.Organization[0], Organization: ca.cert.Subject.Organization},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	return &testCA{cert: createTestCertificate(t, template, ca.cert, &key.PublicKey, ca.key), key: key}
+}
+
+// issue creates the certificate of cn with the organizational units ous, for publicKey
+func (ca *testCA) issue(t *testing.T, cn string, publicKey crypto.PublicKey, ous ...string) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: newTestSerialNumber(),
+		Subject:      pkix.Name{CommonName: cn, OrganizationalUnit: ous},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	return createTestCertificate(t, template, ca.cert, publicKey, ca.key)
+}
+
+// msp is the MSP of ca alone
+func (ca *testCA) msp() *MSP {
+	return &MSP{RootCerts: []*x509.Certificate{ca.cert}}
+}
+
+func createTestCertificate(t *testing.T, template, parent *x509.Certificate, publicKey crypto.PublicKey, key *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, publicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// testSigner is an identity of the tests with its key
+type testSigner struct {
+	identity []byte // a marshaled msp.SerializedIdentity
+	key      *ecdsa.PrivateKey
+}
+
+// signer issues the certificate of a new ECDSA key of cn, as an identity of mspid
+func (ca *testCA) signer(t *testing.T, mspid string, cn string, ous ...string) *testSigner {
+	t.Helper()
+	key := newTestKey(t)
+	cert := ca.issue(t, cn, &key.PublicKey, ous...)
+	return &testSigner{identity: testIdentity(t, mspid, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})), key: key}
+}
+
+func testIdentity(t *testing.T, mspid string, idBytes []byte) []byte {
+	t.Helper()
+	return testMarshal(t, &msp.SerializedIdentity{Mspid: mspid, IdBytes: idBytes})
+}
+
+// sign signs message like the bccsp of a Fabric peer, ECDSA over its SHA-256 with a low S
+func (s *testSigner) sign(t *testing.T, message []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(message)
+	r, sv, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sv.Cmp(halfOrder(s.key.Curve)) > 0 {
+		sv.Sub(s.key.Curve.Params().N, sv)
+	}
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, sv})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+// testMarshaler is what the messages of fabric-protos-go implement
+type testMarshaler interface {
+	XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
+}
+
+func testMarshal(t *testing.T, message testMarshaler) []byte {
+	t.Helper()
+	data, err := message.XXX_Marshal(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// testTransaction is an endorser transaction, created by creator and endorsed by endorsers
+type testTransaction struct {
+	creator   *testSigner
+	endorsers []*testSigner
+	txId      string                                 // the TxId Fabric computes when empty
+	tamper    func(endorsements []*peer.Endorsement) // changes the endorsements once they are signed
+
+	chaincodeActionPayload []byte // replaces the marshaled peer.ChaincodeActionPayload when set, e.g. with malformed bytes
+}
+
+var testNonce = []byte("0123456789abcdef01234567")
+
+// marshal returns the transaction as a marshaled peer.ProcessedTransaction, like qscc GetTransactionByID returns it
+func (tt *testTransaction) marshal(t *testing.T) []byte {
+	t.Helper()
+	return testMarshal(t, &peer.ProcessedTransaction{TransactionEnvelope: tt.envelope(t)})
+}
+
+// envelope returns the signed envelope of the transaction, like it is in the data of a block
+func (tt *testTransaction) envelope(t *testing.T) *common.Envelope {
+	t.Helper()
+	txId := tt.txId
+	if txId == "" {
+		txId = computeTxId(testNonce, tt.creator.identity)
+	}
+	extension := testMarshal(t, &peer.ChaincodeHeaderExtension{ChaincodeId: &peer.ChaincodeID{Name: "basic"}})
+	channelHeader := testMarshal(t, &common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION), ChannelId: "mychannel", TxId: txId, Timestamp: timestamppb.Now(), Extension: extension})
+	signatureHeader := testMarshal(t, &common.SignatureHeader{Creator: tt.creator.identity, Nonce: testNonce})
+
+	chaincodeAction := testMarshal(t, &peer.ChaincodeAction{Response: &peer.Response{Status: 200}, ChaincodeId: &peer.ChaincodeID{Name: "basic", Version: "1.0"}})
+	proposalResponsePayload := testMarshal(t, &peer.ProposalResponsePayload{ProposalHash: []byte("hash"), Extension: chaincodeAction})
+	endorsements := []*peer.Endorsement{}
+	for _, endorser := range tt.endorsers {
+		message := append(append([]byte{}, proposalResponsePayload...), endorser.identity...)
+		endorsements = append(endorsements, &peer.Endorsement{Endorser: endorser.identity, Signature: endorser.sign(t, message)})
+	}
+	if tt.tamper != nil {
+		tt.tamper(endorsements)
+	}
+
+	input := testMarshal(t, &peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{Type: peer.ChaincodeSpec_GOLANG, ChaincodeId: &peer.ChaincodeID{Name: "basic"}, Input: &peer.ChaincodeInput{Args: [][]byte{[]byte("CreateAsset"), []byte("asset1")}}}})
+	chaincodeProposalPayload := testMarshal(t, &peer.ChaincodeProposalPayload{Input: input})
+	chaincodeActionPayload := testMarshal(t, &peer.ChaincodeActionPayload{ChaincodeProposalPayload: chaincodeProposalPayload, Action: &peer.ChaincodeEndorsedAction{ProposalResponsePayload: proposalResponsePayload, Endorsements: endorsements}})
+	if tt.chaincodeActionPayload != nil {
+		chaincodeActionPayload = tt.chaincodeActionPayload
+	}
+	transaction := testMarshal(t, &peer.Transaction{Actions: []*peer.TransactionAction{{Header: signatureHeader, Payload: chaincodeActionPayload}}})
+	payload := testMarshal(t, &common.Payload{Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader}, Data: transaction})
+	return &common.Envelope{Payload: payload, Signature: tt.creator.sign(t, payload)}
+}
+
+// testEnvelope returns the envelope of a payload with a channel header of headerType and data, unsigned like
+// the envelopes of config blocks
+func testEnvelope(t *testing.T, headerType common.HeaderType, data []byte) *common.Envelope {
+	t.Helper()
+	channelHeader := testMarshal(t, &common.ChannelHeader{Type: int32(headerType), ChannelId: "mychannel", Timestamp: timestamppb.Now()})
+	signatureHeader := testMarshal(t, &common.SignatureHeader{Nonce: testNonce})
+	return &common.Envelope{Payload: testMarshal(t, &common.Payload{Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader}, Data: data})}
+}
+
+// testBlock returns a marshaled common.Block of the envelopes, like qscc GetBlockByNumber returns it
+func testBlock(t *testing.T, number uint64, metadata [][]byte, envelopes ...*common.Envelope) []byte {
+	t.Helper()
+	data := [][]byte{}
+	for _, envelope := range envelopes {
+		data = append(data, testMarshal(t, envelope))
+	}
+	header := &common.BlockHeader{Number: number, PreviousHash: []byte("previous hash"), DataHash: []byte("data hash")}
+	return testMarshal(t, &common.Block{Header: header, Data: &common.BlockData{Data: data}, Metadata: &common.BlockMetadata{Metadata: metadata}})
+}
+
+// endorsedAction returns the chaincode endorsed action of a transaction decoded from testTransaction
+func endorsedAction(t *testing.T, processedTransaction *ParsedProcessedTransaction) *ParsedChaincodeEndorsedAction {
+	t.Helper()
+	data, ok := processedTransaction.TransactionEnvelope.Payload.Data.(*ParsedData)
+	if !ok || len(data.Actions) != 1 {
+		t.Fatalf("the transaction has no action: %+v", processedTransaction.TransactionEnvelope.Payload.Data)
+	}
+	return data.Actions[0].Payload.Action
+}
+
+// testChannelGroup returns the channel group of a config with the application organization of ca as Org1MSP,
+// and an etcdraft orderer
+func testChannelGroup(t *testing.T, ca *testCA) *common.ConfigGroup {
+	t.Helper()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	nodeOUs := &msp.FabricNodeOUs{
+		Enable:              true,
+		ClientOuIdentifier:  &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "client"},
+		PeerOuIdentifier:    &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "peer"},
+		AdminOuIdentifier:   &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "admin"},
+		OrdererOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "orderer"},
+	}
+	mspConfig := testMarshal(t, &msp.MSPConfig{Type: 0, Config: testMarshal(t, &msp.FabricMSPConfig{Name: "Org1MSP", RootCerts: [][]byte{caPEM}, FabricNodeOus: nodeOUs})})
+	admins := testMarshal(t, &common.SignaturePolicyEnvelope{
+		Rule:       &common.SignaturePolicy{Type: &common.SignaturePolicy_NOutOf_{NOutOf: &common.SignaturePolicy_NOutOf{N: 1, Rules: []*common.SignaturePolicy{{Type: &common.SignaturePolicy_SignedBy{SignedBy: 0}}}}}},
+		Identities: []*msp.MSPPrincipal{{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: testMarshal(t, &msp.MSPRole{MspIdentifier: "Org1MSP", Role: msp.MSPRole_ADMIN})}},
+	})
+	organization := &common.ConfigGroup{
+		Values: map[string]*common.ConfigValue{
+			MSPKey:         {Value: mspConfig, ModPolicy: "Admins"},
+			AnchorPeersKey: {Value: testMarshal(t, &peer.AnchorPeers{AnchorPeers: []*peer.AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}}}), ModPolicy: "Admins"},
+		},
+		Policies:  map[string]*common.ConfigPolicy{"Admins": {Policy: &common.Policy{Type: int32(common.Policy_SIGNATURE), Value: admins}, ModPolicy: "Admins"}},
+		ModPolicy: "Admins",
+	}
+	application := &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{"Org1MSP": organization},
+		Values: map[string]*common.ConfigValue{
+			CapabilitiesKey: {Value: testMarshal(t, &common.Capabilities{Capabilities: map[string]*common.Capability{"V2_0": {}}})},
+			ACLsKey:         {Value: testMarshal(t, &peer.ACLs{Acls: map[string]*peer.APIResource{"qscc/GetBlockByNumber": {PolicyRef: "/Channel/Application/Readers"}}})},
+		},
+		Policies: map[string]*common.ConfigPolicy{"Admins": {Policy: &common.Policy{
+			Type:  int32(common.Policy_IMPLICIT_META),
+			Value: testMarshal(t, &common.ImplicitMetaPolicy{SubPolicy: "Admins", Rule: common.ImplicitMetaPolicy_MAJORITY}),
+		}}},
+		ModPolicy: "Admins",
+	}
+	raftMetadata := testMarshal(t, &etcdraft.ConfigMetadata{
+		Consenters: []*etcdraft.Consenter{{Host: "orderer0.example.com", Port: 7050, ClientTlsCert: caPEM, ServerTlsCert: caPEM}},
+		Options:    &etcdraft.Options{TickInterval: "500ms", ElectionTick: 10, HeartbeatTick: 1},
+	})
+	ordererGroup := &common.ConfigGroup{Values: map[string]*common.ConfigValue{
+		BatchSizeKey:     {Value: testMarshal(t, &orderer.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 103809024, PreferredMaxBytes: 524288})},
+		BatchTimeoutKey:  {Value: testMarshal(t, &orderer.BatchTimeout{Timeout: "2s"})},
+		ConsensusTypeKey: {Value: testMarshal(t, &orderer.ConsensusType{Type: EtcdraftConsensusType, Metadata: raftMetadata})},
+	}}
+	return &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{"Application": application, "Orderer": ordererGroup},
+		Values: map[string]*common.ConfigValue{
+			HashingAlgorithmKey:          {Value: testMarshal(t, &common.HashingAlgorithm{Name: "SHA256"})},
+			BlockDataHashingStructureKey: {Value: testMarshal(t, &common.BlockDataHashingStructure{Width: 4294967295})},
+			OrdererAddressesKey:          {Value: testMarshal(t, &common.OrdererAddresses{Addresses: []string{"orderer0.example.com:7050"}})},
+		},
+	}
+}
+
+// testConfigUpdate returns the envelope of a config update of channelGroup signed by admin
+func testConfigUpdate(t *testing.T, admin *testSigner, channelGroup *common.ConfigGroup) *common.Envelope {
+	t.Helper()
+	configUpdate := testMarshal(t, &common.ConfigUpdate{ChannelId: "mychannel", ReadSet: &common.ConfigGroup{}, WriteSet: channelGroup})
+	signatureHeader := testMarshal(t, &common.SignatureHeader{Creator: admin.identity, Nonce: testNonce})
+	configSignature := &common.ConfigSignature{SignatureHeader: signatureHeader, Signature: admin.sign(t, append(append([]byte{}, signatureHeader...), configUpdate...))}
+	configUpdateEnvelope := testMarshal(t, &common.ConfigUpdateEnvelope{ConfigUpdate: configUpdate, Signatures: []*common.ConfigSignature{configSignature}})
+	channelHeader := testMarshal(t, &common.ChannelHeader{Type: int32(common.HeaderType_CONFIG_UPDATE), ChannelId: "mychannel"})
+	payload := testMarshal(t, &common.Payload{Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader}, Data: configUpdateEnvelope})
+	return &common.Envelope{Payload: payload, Signature: admin.sign(t, payload)}
+}
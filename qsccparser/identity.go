@@ -0,0 +1,145 @@
+package qsccparser
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// the kinds of the IdBytes of an msp.SerializedIdentity
+const (
+	IdentityX509    = "X509"    // a PEM or DER certificate, decoded into ParsedIdBytes
+	IdentityIdemix  = "Idemix"  // an msp.SerializedIdemixIdentity, decoded into ParsedIdemixIdentity
+	IdentityUnknown = "Unknown" // anything else, kept as the Raw of a failure
+)
+
+// identityKind tells what the IdBytes of an msp.SerializedIdentity are
+func identityKind(idBytes []byte) string {
+	if bl, _ := pem.Decode(idBytes); bl != nil {
+		return IdentityX509
+	}
+	if _, err := x509.ParseCertificate(idBytes); err == nil {
+		return IdentityX509
+	}
+	idemixIdentity := &msp.SerializedIdemixIdentity{}
+	err := idemixIdentity.XXX_Unmarshal(idBytes)
+	if err == nil && len(idemixIdentity.GetNymX()) > 0 && len(idemixIdentity.GetNymY()) > 0 {
+		return IdentityIdemix
+	}
+	return IdentityUnknown
+}
+
+// parseCertificate parses the certificate of the IdBytes of an identity, PEM or DER
+func parseCertificate(idBytes []byte) (*x509.Certificate, error) {
+	der := idBytes
+	if bl, _ := pem.Decode(idBytes); bl != nil {
+		der = bl.Bytes
+	}
+	return x509.ParseCertificate(der)
+}
+
+// withIdentities returns the decoder for a result, which collects its certificates when it dedups identities
+func (d *Decoder) withIdentities() *Decoder {
+	if d == nil || !d.DedupIdentities {
+		return d
+	}
+	resultDecoder := *d
+	resultDecoder.identities = map[string]*ParsedIdBytes{}
+	return &resultDecoder
+}
+
+func (d *Decoder) dedupsIdentities() bool {
+	return d != nil && d.identities != nil
+}
+
+// identitiesOf returns the certificates a decoder from withIdentities collected, nil when there are none
+func (d *Decoder) identitiesOf() map[string]*ParsedIdBytes {
+	if d == nil || len(d.identities) == 0 {
+		return nil
+	}
+	return d.identities
+}
+
+// certificateFingerprint is the hex of the SHA-256 of the DER of a PEM or DER certificate, like
+// `openssl x509 -noout -fingerprint -sha256` prints it without the colons
+func certificateFingerprint(idBytes []byte) string {
+	der := idBytes
+	if bl, _ := pem.Decode(idBytes); bl != nil {
+		der = bl.Bytes
+	}
+	fingerprint := sha256.Sum256(der)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// errUnknownIdentity is the error of the IdBytes of an IdentityUnknown
+func errUnknownIdentity(mspid string) error {
+	return fmt.Errorf("the identity of %s is neither an X.509 certificate nor an Idemix identity", mspid)
+}
+
+type ParsedIdemixIdentity struct {
+	// *msp.SerializedIdemixIdentity
+	NymX     ParsedBytes             //func (*msp.SerializedIdemixIdentity).GetNymX() []byte
+	NymY     ParsedBytes             //func (*msp.SerializedIdemixIdentity).GetNymY() []byte
+	Ou       *ParsedOrganizationUnit //func (*msp.SerializedIdemixIdentity).GetOu() []byte
+	Role     *ParsedMSPRole          //func (*msp.SerializedIdemixIdentity).GetRole() []byte
+	Proof    ParsedBytes             //func (*msp.SerializedIdemixIdentity).GetProof() []byte
+	Failures []*ParsedFailure        `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dii *ParsedIdemixIdentity) DecodeIdemixIdentity(decoder *Decoder, idemixIdentity *msp.SerializedIdemixIdentity) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dii.NymX = decoder.bytes(idemixIdentity.GetNymX())
+	dii.NymY = decoder.bytes(idemixIdentity.GetNymY())
+
+	organizationUnit := &msp.OrganizationUnit{}
+	err := organizationUnit.XXX_Unmarshal(idemixIdentity.GetOu())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dii.Failures, unmarshalError("Ou", organizationUnit, idemixIdentity.GetOu(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedOrganizationUnit := &ParsedOrganizationUnit{}
+	err = decodedOrganizationUnit.DecodeOrganizationUnit(decoder, organizationUnit)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Ou", err)
+	}
+	dii.Ou = decodedOrganizationUnit
+
+	mspRole := &msp.MSPRole{}
+	err = mspRole.XXX_Unmarshal(idemixIdentity.GetRole())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dii.Failures, unmarshalError("Role", mspRole, idemixIdentity.GetRole(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedMSPRole := &ParsedMSPRole{}
+	err = decodedMSPRole.DecodeMSPRole(decoder, mspRole)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Role", err)
+	}
+	dii.Role = decodedMSPRole
+
+	dii.Proof = decoder.bytes(idemixIdentity.GetProof())
+
+	logger.Debug("DecodedIdemixIdentity", "value", dii)
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+func TestDecodeTransactionDedupIdentities(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	user := ca.signer(t, "Org1MSP", "user1", "client")
+	peer0 := ca.signer(t, "Org1MSP", "peer0", "peer")
+	peer1 := ca.signer(t, "Org1MSP", "peer1", "peer")
+	junk := &testSigner{identity: testIdentity(t, "Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("junk")})), key: newTestKey(t)}
+
+	// a certificate whose Fabric CA attributes are not JSON
+	badAttributesKey := newTestKey(t)
+	badAttributesCert := createTestCertificate(t, &x509.Certificate{
+		SerialNumber:    newTestSerialNumber(),
+		Subject:         pkix.Name{CommonName: "user2"},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: fabricAttributesOID, Value: []byte("{attrs")}},
+	}, ca.cert, &badAttributesKey.PublicKey, ca.key)
+	badAttributes := &testSigner{identity: testIdentity(t, "Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: badAttributesCert.Raw})), key: badAttributesKey}
+
+	tests := []struct {
+		name       string
+		decoder    *Decoder
+		creator    *testSigner
+		endorsers  []*testSigner
+		identities int  // the entries of Identities
+		refs       bool // whether the certificates are only referred to
+	}{
+		{"without dedup", &Decoder{}, user, []*testSigner{peer0, peer0}, 0, false},
+		{"distinct certificates", &Decoder{DedupIdentities: true}, user, []*testSigner{peer0, peer1}, 3, true},
+		{"the same endorser twice", &Decoder{DedupIdentities: true}, user, []*testSigner{peer0, peer0}, 2, true},
+		{"the creator endorses", &Decoder{DedupIdentities: true}, peer0, []*testSigner{peer0}, 1, true},
+		{"a certificate that cannot be parsed", &Decoder{DedupIdentities: true, Lenient: true}, junk, []*testSigner{peer0}, 1, true},
+		{"a certificate that cannot be parsed twice", &Decoder{DedupIdentities: true, Lenient: true}, junk, []*testSigner{junk}, 0, false},
+		{"a certificate with a failure", &Decoder{DedupIdentities: true, Lenient: true}, badAttributes, []*testSigner{badAttributes}, 1, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transaction := &testTransaction{creator: test.creator, endorsers: test.endorsers}
+			// a lenient Decoder returns the certificates it cannot parse together with the result
+			processedTransaction, err := test.decoder.DecodeTransaction(transaction.marshal(t))
+			if processedTransaction == nil || (err != nil && !test.decoder.Lenient) {
+				t.Fatal(err)
+			}
+			if len(processedTransaction.Identities) != test.identities {
+				t.Errorf("Identities has %d entries, want %d", len(processedTransaction.Identities), test.identities)
+			}
+
+			identities := []*ParsedSerializedIdentity{processedTransaction.TransactionEnvelope.Payload.Header.SignatureHeader.Creator}
+			for _, endorsement := range endorsedAction(t, processedTransaction).Endorsements {
+				identities = append(identities, endorsement.Endorser)
+			}
+			for i, identity := range identities {
+				idBytes := identity.IdBytes
+				if identity.Mspid != "Org1MSP" || idBytes == nil {
+					t.Fatalf("identity %d = %+v", i, identity)
+				}
+				switch {
+				case idBytes.Raw != nil:
+					// a certificate that cannot be parsed is kept where it is, with its failure, and not registered
+					if idBytes.Ref != "" || len(idBytes.Failures) == 0 {
+						t.Errorf("identity %d Ref = %q, Failures = %v, want the failure alone", i, idBytes.Ref, idBytes.Failures)
+					}
+				case test.refs:
+					entry, ok := processedTransaction.Identities[idBytes.Ref]
+					if !ok || entry.Subject == "" || entry.Ref != "" {
+						t.Errorf("identity %d Ref = %q, want the fingerprint of a decoded entry of Identities", i, idBytes.Ref)
+					}
+					// the failures of a certificate are on its entry, for every identity that refers to it
+					if ok && (len(entry.Failures) > 0) != (test.creator == badAttributes) {
+						t.Errorf("identity %d Failures = %v", i, entry.Failures)
+					}
+					data, err := json.Marshal(idBytes)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if string(data) != `{"Ref":"`+idBytes.Ref+`"}` {
+						t.Errorf("identity %d = %s, want the Ref alone", i, data)
+					}
+				default:
+					if idBytes.Ref != "" || idBytes.Subject == "" {
+						t.Errorf("identity %d Ref = %q, Subject = %q, want the certificate in place", i, idBytes.Ref, idBytes.Subject)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateFingerprint(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	der := ca.cert.Raw
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	other := ca.issue(t, "user1", &newTestKey(t).PublicKey).Raw
+
+	tests := []struct {
+		name  string
+		a, b  []byte
+		equal bool
+	}{
+		{"PEM and DER", pemBytes, der, true},
+		{"the same PEM", pemBytes, append([]byte{}, pemBytes...), true},
+		{"other certificates", der, other, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := certificateFingerprint(test.a), certificateFingerprint(test.b)
+			if (a == b) != test.equal || len(a) != 64 {
+				t.Errorf("certificateFingerprint() = %s and %s, want equal %v", a, b, test.equal)
+			}
+		})
+	}
+}
+
+func TestIdentityKind(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	der := ca.issue(t, "user1", &newTestKey(t).PublicKey).Raw
+	role := testMarshal(t, &msp.MSPRole{MspIdentifier: "IdemixMSP", Role: msp.MSPRole_PEER})
+	idemix := testMarshal(t, &msp.SerializedIdemixIdentity{NymX: []byte{1}, NymY: []byte{2}, Role: role, Proof: []byte("proof")})
+
+	tests := []struct {
+		name    string
+		idBytes []byte
+		kind    string
+	}{
+		{"PEM", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), IdentityX509},
+		{"DER", der, IdentityX509},
+		{"Idemix", idemix, IdentityIdemix},
+		{"Idemix without NymY", testMarshal(t, &msp.SerializedIdemixIdentity{NymX: []byte{1}, Role: role}), IdentityUnknown},
+		{"truncated DER", der[:len(der)/2], IdentityUnknown},
+		{"junk", []byte("junk"), IdentityUnknown},
+		{"malformed protobuf", []byte{0x0a, 0xff}, IdentityUnknown},
+		{"empty", []byte{}, IdentityUnknown},
+		{"nil", nil, IdentityUnknown},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if kind := identityKind(test.idBytes); kind != test.kind {
+				t.Fatalf("identityKind() = %s, want %s", kind, test.kind)
+			}
+
+			for _, decoder := range []*Decoder{{}, {Lenient: true}, {VerifySignatures: true, MSPs: map[string]*MSP{"Org1MSP": ca.msp()}}} {
+				decodedIdentity := &ParsedSerializedIdentity{}
+				err := decodedIdentity.DecodeSerializedIdentity(decoder, &msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: test.idBytes})
+				if decodedIdentity.Kind != test.kind {
+					t.Errorf("Kind = %s, want %s", decodedIdentity.Kind, test.kind)
+				}
+				switch test.kind {
+				case IdentityX509:
+					if err != nil || decodedIdentity.IdBytes == nil || decodedIdentity.IdBytes.Subject == "" {
+						t.Errorf("DecodeSerializedIdentity() = %v with the IdBytes %+v, want the certificate", err, decodedIdentity.IdBytes)
+					}
+				case IdentityIdemix:
+					if err != nil || decodedIdentity.Idemix == nil || decodedIdentity.Idemix.Role == nil || decodedIdentity.Idemix.Role.Role != "PEER" {
+						t.Errorf("DecodeSerializedIdentity() = %v with Idemix %+v, want the Idemix identity", err, decodedIdentity.Idemix)
+					}
+				case IdentityUnknown:
+					// an unknown identity is a failure that keeps the raw bytes, never a panic
+					if decoder.Lenient {
+						if err != nil || len(decodedIdentity.Failures) != 1 || decodedIdentity.Failures[0].Field != "IdBytes" {
+							t.Errorf("DecodeSerializedIdentity() = %v with the Failures %v, want the IdBytes failure", err, decodedIdentity.Failures)
+						}
+						continue
+					}
+					decodeErr, ok := err.(*DecodeError)
+					if !ok || decodeErr.Path != "IdBytes" || decodeErr.Length != len(test.idBytes) {
+						t.Errorf("DecodeSerializedIdentity() = %v, want a DecodeError of the IdBytes", err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionUnknownCreator(t *testing.T) {
+	junk := &testSigner{identity: testIdentity(t, "Org1MSP", []byte("junk")), key: newTestKey(t)}
+	transaction := (&testTransaction{creator: junk, endorsers: []*testSigner{junk}}).marshal(t)
+
+	policy, err := ParsePolicy("OR('Org1MSP.member')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = (&Decoder{}).DecodeTransaction(transaction)
+	if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "TransactionEnvelope.Payload.Header.SignatureHeader.Creator.IdBytes" {
+		t.Errorf("DecodeTransaction() = %v, want the DecodeError of the creator", err)
+	}
+
+	processedTransaction, err := (&Decoder{Lenient: true, VerifySignatures: true, EndorsementPolicy: policy, DedupIdentities: true}).DecodeTransaction(transaction)
+	if processedTransaction == nil || err == nil {
+		t.Fatalf("DecodeTransaction() = %v, %v, want the result and its DecodeErrors", processedTransaction, err)
+	}
+	envelope := processedTransaction.TransactionEnvelope
+	if envelope.SignatureValid == nil || *envelope.SignatureValid || !strings.Contains(envelope.SignatureReason, "neither an X.509 certificate nor an Idemix identity") {
+		t.Errorf("SignatureValid = %v, SignatureReason = %q", envelope.SignatureValid, envelope.SignatureReason)
+	}
+	if evaluation := endorsedAction(t, processedTransaction).EndorsementPolicy; evaluation == nil || evaluation.Satisfied {
+		t.Errorf("EndorsementPolicy = %+v, want it not satisfied by an unknown endorser", evaluation)
+	}
+}
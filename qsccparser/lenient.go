@@ -0,0 +1,97 @@
+package qsccparser
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParsedFailure records a part of a node that a lenient Decoder could not decode
+type ParsedFailure struct {
+	Field   string // the part of the node, e.g. Payload, empty for the node itself
+	Message string // what the bytes were decoded as, see DecodeError
+	Error   string
+	Raw     ParsedBytes // the bytes that could not be decoded
+	Offset  int         // of Raw in the response, see DecodeError
+
+	err *DecodeError
+}
+
+func (d *Decoder) lenient() bool {
+	return d != nil && d.Lenient
+}
+
+// fail returns err when decoding has to stop. A lenient decoder records err in failures instead and returns nil.
+func (d *Decoder) fail(failures *[]*ParsedFailure, err error) error {
+	decodeErr, ok := err.(*DecodeError)
+	if !ok || !d.lenient() {
+		return err
+	}
+	*failures = append(*failures, &ParsedFailure{
+		Field:   decodeErr.Path,
+		Message: decodeErr.Message,
+		Error:   decodeErr.Err.Error(),
+		Raw:     d.bytes(decodeErr.raw),
+		Offset:  decodeErr.Offset,
+		err:     decodeErr,
+	})
+	return nil
+}
+
+// collectWarnings gathers the failures recorded anywhere below root into warnings, with the
+// paths of their DecodeErrors completed from root and their offsets located in the response data.
+// It returns them as DecodeErrors, or nil if there are none.
+func collectWarnings(root interface{}, data []byte, warnings *[]string) error {
+	decodeErrors := DecodeErrors{}
+	collectFailures(reflect.ValueOf(root), "", data, &decodeErrors)
+	if len(decodeErrors) == 0 {
+		return nil
+	}
+
+	for _, decodeErr := range decodeErrors {
+		*warnings = append(*warnings, decodeErr.Error())
+	}
+	return decodeErrors
+}
+
+func collectFailures(value reflect.Value, path string, data []byte, decodeErrors *DecodeErrors) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			collectFailures(value.Elem(), path, data, decodeErrors)
+		}
+	case reflect.Struct:
+		if !strings.HasPrefix(value.Type().Name(), "Parsed") {
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if failures, ok := value.Field(i).Interface().([]*ParsedFailure); ok {
+				for _, failure := range failures {
+					locateError(data, failure.err)
+					failure.Offset = failure.err.Offset
+					*decodeErrors = append(*decodeErrors, prefixPath(path, failure.err).(*DecodeError))
+				}
+				continue
+			}
+			collectFailures(value.Field(i), joinPath(path, field.Name), data, decodeErrors)
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			collectFailures(value.Index(i), path+"["+strconv.Itoa(i)+"]", data, decodeErrors)
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			collectFailures(value.MapIndex(key), path+"["+key.String()+"]", data, decodeErrors)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package qsccparser
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLenientDecodeTransaction(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	junk := &testSigner{identity: testIdentity(t, "Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("junk")})), key: newTestKey(t)}
+
+	tests := []struct {
+		name        string
+		transaction *testTransaction
+		paths       []string // of the DecodeErrors, in the order of the Warnings
+	}{
+		{"well-formed", &testTransaction{creator: creator}, nil},
+		{"malformed chaincode action payload", &testTransaction{creator: creator, chaincodeActionPayload: []byte{0x0a, 0xff}}, []string{
+			"TransactionEnvelope.Payload.Data.Actions[0].Payload",
+		}},
+		{"malformed creator certificate", &testTransaction{creator: junk}, []string{
+			"TransactionEnvelope.Payload.Header.SignatureHeader.Creator.IdBytes",
+			"TransactionEnvelope.Payload.Data.Actions[0].Header.Creator.IdBytes",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			processedTransaction, err := (&Decoder{Lenient: true}).DecodeTransaction(test.transaction.marshal(t))
+			if processedTransaction == nil {
+				t.Fatalf("DecodeTransaction() = nil, %v", err)
+			}
+			if len(test.paths) == 0 {
+				if err != nil || processedTransaction.Warnings != nil {
+					t.Errorf("DecodeTransaction() = %v with the Warnings %v, want none", err, processedTransaction.Warnings)
+				}
+				return
+			}
+
+			// the DecodeErrors are returned together with the result, and listed in its Warnings
+			var decodeErrors DecodeErrors
+			if !errors.As(err, &decodeErrors) {
+				t.Fatalf("DecodeTransaction() = %v, want DecodeErrors", err)
+			}
+			paths := []string{}
+			for _, decodeErr := range decodeErrors {
+				paths = append(paths, decodeErr.Path)
+			}
+			if !reflect.DeepEqual(paths, test.paths) {
+				t.Errorf("the paths of the DecodeErrors = %v, want %v", paths, test.paths)
+			}
+			if len(processedTransaction.Warnings) != len(decodeErrors) {
+				t.Fatalf("Warnings = %v, want %d", processedTransaction.Warnings, len(decodeErrors))
+			}
+			for i, warning := range processedTransaction.Warnings {
+				if warning != decodeErrors[i].Error() || !strings.Contains(warning, " at "+test.paths[i]+": ") {
+					t.Errorf("Warnings[%d] = %q, want the DecodeError at %s", i, warning, test.paths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLenientFailureAtItsNode(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	malformed := []byte{0x0a, 0xff}
+	transaction := &testTransaction{creator: ca.signer(t, "Org1MSP", "user1", "client"), chaincodeActionPayload: malformed}
+
+	processedTransaction, _ := (&Decoder{Lenient: true}).DecodeTransaction(transaction.marshal(t))
+	data, ok := processedTransaction.TransactionEnvelope.Payload.Data.(*ParsedData)
+	if !ok || len(data.Actions) != 1 {
+		t.Fatalf("Data = %+v, want the action decoded past its payload", processedTransaction.TransactionEnvelope.Payload.Data)
+	}
+	action := data.Actions[0]
+	// the rest of the action is still decoded
+	if action.Header == nil || action.Header.Creator == nil {
+		t.Errorf("Actions[0].Header = %+v, want the creator", action.Header)
+	}
+	if len(action.Failures) != 1 {
+		t.Fatalf("Actions[0].Failures = %v, want 1", action.Failures)
+	}
+
+	// the failure keeps the raw bytes and the error, with the path relative to the node
+	failure := action.Failures[0]
+	if failure.Field != "Payload" || failure.Message != "protos.ChaincodeActionPayload" || failure.Error == "" {
+		t.Errorf("Failures[0] = %+v", failure)
+	}
+	raw, err := json.Marshal(failure.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := json.Marshal(malformed); string(raw) != string(want) {
+		t.Errorf("Failures[0].Raw = %s, want %s", raw, want)
+	}
+	if failure.Offset < 0 {
+		t.Errorf("Failures[0].Offset = %d, want the offset of Raw", failure.Offset)
+	}
+}
+
+func TestStrictDecodeTransaction(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	junk := &testSigner{identity: testIdentity(t, "Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("junk")})), key: newTestKey(t)}
+
+	tests := []struct {
+		name        string
+		transaction *testTransaction
+		path        string // of the first DecodeError
+		message     string
+	}{
+		{"malformed chaincode action payload", &testTransaction{creator: creator, chaincodeActionPayload: []byte{0x0a, 0xff}}, "TransactionEnvelope.Payload.Data.Actions[0].Payload", "protos.ChaincodeActionPayload"},
+		// the creator certificate is in the signature header and again in the action header
+		{"malformed creator certificate", &testTransaction{creator: junk}, "TransactionEnvelope.Payload.Header.SignatureHeader.Creator.IdBytes", "x509.Certificate"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, decoder := range []*Decoder{nil, {}} {
+				processedTransaction, err := decoder.DecodeTransaction(test.transaction.marshal(t))
+				if processedTransaction != nil {
+					t.Errorf("DecodeTransaction() = %+v, want nil", processedTransaction)
+				}
+				// a strict decoder stops at the first failure, rather than collecting them into DecodeErrors
+				decodeErr, ok := err.(*DecodeError)
+				if !ok {
+					t.Fatalf("DecodeTransaction() = %T %v, want a *DecodeError", err, err)
+				}
+				if decodeErr.Path != test.path || decodeErr.Message != test.message {
+					t.Errorf("DecodeError Path = %q, Message = %q, want %q, %q", decodeErr.Path, decodeErr.Message, test.path, test.message)
+				}
+				if decodeErr.Err == nil || errors.Unwrap(decodeErr) != decodeErr.Err {
+					t.Errorf("DecodeError Err = %v, want the cause", decodeErr.Err)
+				}
+			}
+		})
+	}
+}
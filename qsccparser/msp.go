@@ -0,0 +1,441 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"gopkg.in/yaml.v3"
+)
+
+// MSP holds the CA certificates of an MSP, that the identities it issued are validated against, and what
+// classifies these identities by role
+type MSP struct {
+	RootCerts         []*x509.Certificate
+	IntermediateCerts []*x509.Certificate
+	Admins            []*x509.Certificate // the identities that are admins whatever their organizational units
+	NodeOUs           *NodeOUs            // nil when the MSP does not classify identities by organizational unit
+}
+
+// NodeOUs are the organizational units that make an identity a client, peer, admin or orderer, like the
+// NodeOUs of the config.yaml of a local MSP or the FabricNodeOus of an msp.FabricMSPConfig
+type NodeOUs struct {
+	Enable              bool
+	ClientOUIdentifier  *OUIdentifier
+	PeerOUIdentifier    *OUIdentifier
+	AdminOUIdentifier   *OUIdentifier
+	OrdererOUIdentifier *OUIdentifier
+}
+
+// OUIdentifier is an organizational unit, of the identities issued by Certificate if it is set
+type OUIdentifier struct {
+	OrganizationalUnitIdentifier string
+	Certificate                  *x509.Certificate
+}
+
+// the NodeOUs of a config.yaml, with the certificates as paths relative to the MSP directory
+type nodeOUsYAML struct {
+	Enable              bool              `yaml:"Enable"`
+	ClientOUIdentifier  *ouIdentifierYAML `yaml:"ClientOUIdentifier"`
+	PeerOUIdentifier    *ouIdentifierYAML `yaml:"PeerOUIdentifier"`
+	AdminOUIdentifier   *ouIdentifierYAML `yaml:"AdminOUIdentifier"`
+	OrdererOUIdentifier *ouIdentifierYAML `yaml:"OrdererOUIdentifier"`
+}
+
+type ouIdentifierYAML struct {
+	Certificate                  string `yaml:"Certificate"`
+	OrganizationalUnitIdentifier string `yaml:"OrganizationalUnitIdentifier"`
+}
+
+// ReadMSPs reads the CA certificates of the MSPs in dir, keyed by Mspid. Each MSP is a directory named
+// after its Mspid and laid out like a local MSP: <dir>/<Mspid>/cacerts/*.pem and <dir>/<Mspid>/intermediatecerts/*.pem,
+// and optionally <dir>/<Mspid>/admincerts/*.pem and the NodeOUs of <dir>/<Mspid>/config.yaml
+func ReadMSPs(dir string) (map[string]*MSP, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	msps := map[string]*MSP{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		rootCerts, err := readCertificates(filepath.Join(dir, entry.Name(), "cacerts"))
+		if err != nil {
+			return nil, err
+		}
+		intermediateCerts, err := readCertificates(filepath.Join(dir, entry.Name(), "intermediatecerts"))
+		if err != nil {
+			return nil, err
+		}
+		admins, err := readCertificates(filepath.Join(dir, entry.Name(), "admincerts"))
+		if err != nil {
+			return nil, err
+		}
+		nodeOUs, err := readNodeOUs(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		msps[entry.Name()] = &MSP{RootCerts: rootCerts, IntermediateCerts: intermediateCerts, Admins: admins, NodeOUs: nodeOUs}
+	}
+	return msps, nil
+}
+
+// readCertificates reads every certificate of the PEM files in dir, a missing dir has none
+func readCertificates(dir string) ([]*x509.Certificate, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	certs := []*x509.Certificate{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileCerts, err := parseCertificates(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		certs = append(certs, fileCerts...)
+	}
+	return certs, nil
+}
+
+// readNodeOUs reads the NodeOUs of the config.yaml in mspDir, a missing config.yaml has none
+func readNodeOUs(mspDir string) (*NodeOUs, error) {
+	file := filepath.Join(mspDir, "config.yaml")
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		NodeOUs *nodeOUsYAML `yaml:"NodeOUs"`
+	}{}
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if config.NodeOUs == nil {
+		return nil, nil
+	}
+
+	nodeOUs := &NodeOUs{Enable: config.NodeOUs.Enable}
+	for _, ou := range []struct {
+		identifier **OUIdentifier
+		config     *ouIdentifierYAML
+	}{
+		{&nodeOUs.ClientOUIdentifier, config.NodeOUs.ClientOUIdentifier},
+		{&nodeOUs.PeerOUIdentifier, config.NodeOUs.PeerOUIdentifier},
+		{&nodeOUs.AdminOUIdentifier, config.NodeOUs.AdminOUIdentifier},
+		{&nodeOUs.OrdererOUIdentifier, config.NodeOUs.OrdererOUIdentifier},
+	} {
+		if ou.config == nil {
+			continue
+		}
+		identifier := &OUIdentifier{OrganizationalUnitIdentifier: ou.config.OrganizationalUnitIdentifier}
+		if ou.config.Certificate != "" {
+			certFile := filepath.Join(mspDir, ou.config.Certificate)
+			certData, err := os.ReadFile(certFile)
+			if err != nil {
+				return nil, err
+			}
+			identifier.Certificate, err = parseCertificate(certData)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", certFile, err)
+			}
+		}
+		*ou.identifier = identifier
+	}
+	return nodeOUs, nil
+}
+
+// parseCertificates parses every certificate of a PEM file
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for {
+		var bl *pem.Block
+		bl, data = pem.Decode(data)
+		if bl == nil {
+			return certs, nil
+		}
+		cert, err := x509.ParseCertificate(bl.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// MSPs returns the MSPs of the organizations in a config block, keyed by Mspid. It returns none for any other block,
+// and misses the MSPs whose nodes were dropped by the MaxDepth of the Decoder.
+func (dbd *ParsedBlockData) MSPs() (map[string]*MSP, error) {
+	msps := map[string]*MSP{}
+	for _, configEnvelope := range dbd.configEnvelopes() {
+		err := collectMSPs(configEnvelope.Config.ChannelGroup, msps)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msps, nil
+}
+
+// collectMSPs adds the MSPs of the organizations in configGroup and the groups below it to msps
+func collectMSPs(configGroup *ParsedConfigGroup, msps map[string]*MSP) error {
+	if configGroup == nil {
+		return nil
+	}
+	if mspValue, ok := configGroup.Values[MSPKey]; ok && mspValue != nil {
+		if mspConfig, ok := mspValue.Value.(*ParsedMSPConfig); ok && mspConfig != nil {
+			if fabricMSPConfig, ok := mspConfig.Config.(*ParsedFabricMSPConfig); ok && fabricMSPConfig != nil {
+				decodedMSP := &MSP{}
+				for _, rootCert := range fabricMSPConfig.fabricMSPConfig.GetRootCerts() {
+					certs, err := parseCertificates(rootCert)
+					if err != nil {
+						return fmt.Errorf("root certificate of %s: %w", fabricMSPConfig.Name, err)
+					}
+					decodedMSP.RootCerts = append(decodedMSP.RootCerts, certs...)
+				}
+				for _, intermediateCert := range fabricMSPConfig.fabricMSPConfig.GetIntermediateCerts() {
+					certs, err := parseCertificates(intermediateCert)
+					if err != nil {
+						return fmt.Errorf("intermediate certificate of %s: %w", fabricMSPConfig.Name, err)
+					}
+					decodedMSP.IntermediateCerts = append(decodedMSP.IntermediateCerts, certs...)
+				}
+				for _, admin := range fabricMSPConfig.fabricMSPConfig.GetAdmins() {
+					certs, err := parseCertificates(admin)
+					if err != nil {
+						return fmt.Errorf("admin certificate of %s: %w", fabricMSPConfig.Name, err)
+					}
+					decodedMSP.Admins = append(decodedMSP.Admins, certs...)
+				}
+				nodeOUs, err := configNodeOUs(fabricMSPConfig.fabricMSPConfig.GetFabricNodeOus())
+				if err != nil {
+					return fmt.Errorf("node OUs of %s: %w", fabricMSPConfig.Name, err)
+				}
+				decodedMSP.NodeOUs = nodeOUs
+				msps[fabricMSPConfig.Name] = decodedMSP
+			}
+		}
+	}
+	for _, group := range configGroup.Groups {
+		err := collectMSPs(group, msps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configNodeOUs converts the FabricNodeOus of an msp.FabricMSPConfig
+func configNodeOUs(fabricNodeOUs *msp.FabricNodeOUs) (*NodeOUs, error) {
+	if fabricNodeOUs == nil {
+		return nil, nil
+	}
+	nodeOUs := &NodeOUs{Enable: fabricNodeOUs.GetEnable()}
+	for _, ou := range []struct {
+		identifier **OUIdentifier
+		config     *msp.FabricOUIdentifier
+	}{
+		{&nodeOUs.ClientOUIdentifier, fabricNodeOUs.GetClientOuIdentifier()},
+		{&nodeOUs.PeerOUIdentifier, fabricNodeOUs.GetPeerOuIdentifier()},
+		{&nodeOUs.AdminOUIdentifier, fabricNodeOUs.GetAdminOuIdentifier()},
+		{&nodeOUs.OrdererOUIdentifier, fabricNodeOUs.GetOrdererOuIdentifier()},
+	} {
+		if ou.config == nil {
+			continue
+		}
+		identifier := &OUIdentifier{OrganizationalUnitIdentifier: ou.config.GetOrganizationalUnitIdentifier()}
+		if len(ou.config.GetCertificate()) > 0 {
+			cert, err := parseCertificate(ou.config.GetCertificate())
+			if err != nil {
+				return nil, err
+			}
+			identifier.Certificate = cert
+		}
+		*ou.identifier = identifier
+	}
+	return nodeOUs, nil
+}
+
+// ParsedIdentityValidation tells whether the certificate of an identity chains to the CAs of its MSP. The chain is
+// verified at the ChannelHeader.Timestamp of the transaction the identity belongs to, or at the time of decoding
+// for an identity outside of a transaction, e.g. the signer of a block.
+type ParsedIdentityValidation struct {
+	ChainValid       bool
+	ChainReason      string   `json:",omitempty"` //why the chain is not valid
+	ChainPath        []string `json:",omitempty"` //subjects from the certificate up to the root CA
+	ValidAtTimestamp *bool    `json:",omitempty"` //whether the ChannelHeader.Timestamp of the transaction is within the validity of the certificate
+}
+
+func (d *Decoder) validatesIdentities() bool {
+	return d != nil && d.MSPs != nil
+}
+
+// withChannelHeader returns the decoder for the parts of a payload, which validates identities at the
+// timestamp of the channel header of the payload
+func (d *Decoder) withChannelHeader(channelHeaderBytes []byte) *Decoder {
+	if !d.validatesIdentities() {
+		return d
+	}
+	channelHeader := &common.ChannelHeader{}
+	err := channelHeader.XXX_Unmarshal(channelHeaderBytes)
+	if err != nil || channelHeader.GetTimestamp() == nil {
+		return d
+	}
+
+	timestamp := channelHeader.GetTimestamp().AsTime()
+	payloadDecoder := *d
+	payloadDecoder.transactionTime = &timestamp
+	return &payloadDecoder
+}
+
+func (d *Decoder) validateIdentity(serializedIdentity *msp.SerializedIdentity) *ParsedIdentityValidation {
+	validation := &ParsedIdentityValidation{}
+
+	mspCAs, ok := d.MSPs[serializedIdentity.GetMspid()]
+	if !ok {
+		validation.ChainReason = fmt.Sprintf("no CA certificates for MSP %s", serializedIdentity.GetMspid())
+		return validation
+	}
+
+	if identityKind(serializedIdentity.GetIdBytes()) != IdentityX509 {
+		validation.ChainReason = "the identity is not an X.509 certificate"
+		return validation
+	}
+	cert, err := parseCertificate(serializedIdentity.GetIdBytes())
+	if err != nil {
+		validation.ChainReason = err.Error()
+		return validation
+	}
+
+	verifyTime := time.Now()
+	if d.transactionTime != nil {
+		verifyTime = *d.transactionTime
+		validAtTimestamp := !verifyTime.Before(cert.NotBefore) && !verifyTime.After(cert.NotAfter)
+		validation.ValidAtTimestamp = &validAtTimestamp
+	}
+
+	roots := x509.NewCertPool()
+	for _, rootCert := range mspCAs.RootCerts {
+		roots.AddCert(rootCert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediateCert := range mspCAs.IntermediateCerts {
+		intermediates.AddCert(intermediateCert)
+	}
+
+	// like an MSP, any extended key usage is accepted
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   verifyTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		validation.ChainReason = err.Error()
+		return validation
+	}
+
+	validation.ChainValid = true
+	for _, chainCert := range chains[0] {
+		validation.ChainPath = append(validation.ChainPath, chainCert.Subject.String())
+	}
+	return validation
+}
+
+// the roles of ParsedSerializedIdentity.Role
+const (
+	RoleMember  = "member"
+	RoleClient  = "client"
+	RolePeer    = "peer"
+	RoleAdmin   = "admin"
+	RoleOrderer = "orderer"
+)
+
+// classifyIdentity tells the role of an identity in its MSP, and the organization of the MSP: the organization of
+// its first root CA, or the Mspid. Without NodeOUs every identity is a member, with NodeOUs an identity without
+// any of their organizational units has no role, like it is invalid for a Fabric MSP. An Idemix identity
+// carries its role.
+func (d *Decoder) classifyIdentity(serializedIdentity *msp.SerializedIdentity) (role string, org string) {
+	if identityKind(serializedIdentity.GetIdBytes()) == IdentityIdemix {
+		idemixIdentity := &msp.SerializedIdemixIdentity{}
+		mspRole := &msp.MSPRole{}
+		if idemixIdentity.XXX_Unmarshal(serializedIdentity.GetIdBytes()) != nil || mspRole.XXX_Unmarshal(idemixIdentity.GetRole()) != nil {
+			return "", serializedIdentity.GetMspid()
+		}
+		return strings.ToLower(mspRole.GetRole().String()), serializedIdentity.GetMspid()
+	}
+
+	mspCAs, ok := d.MSPs[serializedIdentity.GetMspid()]
+	if !ok {
+		return "", ""
+	}
+	org = serializedIdentity.GetMspid()
+	for _, rootCert := range mspCAs.RootCerts {
+		if len(rootCert.Subject.Organization) > 0 {
+			org = rootCert.Subject.Organization[0]
+			break
+		}
+	}
+
+	cert, err := parseCertificate(serializedIdentity.GetIdBytes())
+	if err != nil {
+		return "", org
+	}
+	for _, admin := range mspCAs.Admins {
+		if cert.Equal(admin) {
+			return RoleAdmin, org
+		}
+	}
+	if mspCAs.NodeOUs == nil || !mspCAs.NodeOUs.Enable {
+		return RoleMember, org
+	}
+	for _, nodeOU := range []struct {
+		role       string
+		identifier *OUIdentifier
+	}{
+		{RoleAdmin, mspCAs.NodeOUs.AdminOUIdentifier},
+		{RoleClient, mspCAs.NodeOUs.ClientOUIdentifier},
+		{RolePeer, mspCAs.NodeOUs.PeerOUIdentifier},
+		{RoleOrderer, mspCAs.NodeOUs.OrdererOUIdentifier},
+	} {
+		if nodeOU.identifier.matches(cert) {
+			return nodeOU.role, org
+		}
+	}
+	return "", org
+}
+
+// matches reports whether cert has the organizational unit, and was issued by the Certificate of the identifier if it is set
+func (oi *OUIdentifier) matches(cert *x509.Certificate) bool {
+	if oi == nil {
+		return false
+	}
+	if oi.Certificate != nil && !cert.Equal(oi.Certificate) && cert.CheckSignatureFrom(oi.Certificate) != nil {
+		return false
+	}
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if ou == oi.OrganizationalUnitIdentifier {
+			return true
+		}
+	}
+	return false
+}
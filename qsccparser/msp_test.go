@@ -0,0 +1,239 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+func TestValidateIdentity(t *testing.T) {
+	org1 := newTestCA(t, "org1")
+	org1Intermediate := org1.intermediate(t)
+	org2 := newTestCA(t, "org2")
+	msps := map[string]*MSP{
+		"Org1MSP": {RootCerts: []*x509.Certificate{org1.cert}, IntermediateCerts: []*x509.Certificate{org1Intermediate.cert}},
+		"Org2MSP": org2.msp(),
+		"Org3MSP": {RootCerts: []*x509.Certificate{org1.cert}},
+	}
+	now := time.Now()
+	later := now.Add(2 * time.Hour)
+	valid, expired := true, false
+
+	tests := []struct {
+		name             string
+		identity         []byte
+		transactionTime  *time.Time
+		chainValid       bool
+		chainPath        int    // the length of ChainPath
+		reason           string // a part of ChainReason
+		validAtTimestamp *bool
+	}{
+		{"root CA", org1.signer(t, "Org1MSP", "user1").identity, nil, true, 2, "", nil},
+		{"intermediate CA", org1Intermediate.signer(t, "Org1MSP", "user1").identity, nil, true, 3, "", nil},
+		{"intermediate CA not in the MSP", org1Intermediate.signer(t, "Org3MSP", "user1").identity, nil, false, 0, "unknown authority", nil},
+		{"wrong MSP", org2.signer(t, "Org1MSP", "user1").identity, nil, false, 0, "unknown authority", nil},
+		{"other MSP", org1.signer(t, "Org2MSP", "user1").identity, nil, false, 0, "unknown authority", nil},
+		{"unknown MSP", org1.signer(t, "Org4MSP", "user1").identity, nil, false, 0, "no CA certificates for MSP Org4MSP", nil},
+		{"not a certificate", testIdentity(t, "Org1MSP", []byte("junk")), nil, false, 0, "not an X.509 certificate", nil},
+		{"at the transaction time", org1.signer(t, "Org1MSP", "user1").identity, &now, true, 2, "", &valid},
+		{"expired at the transaction time", org1.signer(t, "Org1MSP", "user1").identity, &later, false, 0, "expired", &expired},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serializedIdentity := &msp.SerializedIdentity{}
+			err := serializedIdentity.XXX_Unmarshal(test.identity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoder := &Decoder{MSPs: msps, transactionTime: test.transactionTime}
+			validation := decoder.validateIdentity(serializedIdentity)
+			if validation.ChainValid != test.chainValid {
+				t.Errorf("ChainValid = %v, want %v: %s", validation.ChainValid, test.chainValid, validation.ChainReason)
+			}
+			if len(validation.ChainPath) != test.chainPath {
+				t.Errorf("ChainPath = %v, want %d subjects", validation.ChainPath, test.chainPath)
+			}
+			if !strings.Contains(validation.ChainReason, test.reason) || (test.reason == "") != (validation.ChainReason == "") {
+				t.Errorf("ChainReason = %q, want %q", validation.ChainReason, test.reason)
+			}
+			if (validation.ValidAtTimestamp == nil) != (test.validAtTimestamp == nil) ||
+				(validation.ValidAtTimestamp != nil && *validation.ValidAtTimestamp != *test.validAtTimestamp) {
+				t.Errorf("ValidAtTimestamp = %v, want %v", validation.ValidAtTimestamp, test.validAtTimestamp)
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionValidatesIdentities(t *testing.T) {
+	org1 := newTestCA(t, "org1")
+	org2 := newTestCA(t, "org2")
+	transaction := &testTransaction{creator: org1.signer(t, "Org1MSP", "user1"), endorsers: []*testSigner{org2.signer(t, "Org1MSP", "peer0")}}
+	decoder := &Decoder{MSPs: map[string]*MSP{"Org1MSP": org1.msp()}}
+	processedTransaction, err := decoder.DecodeTransaction(transaction.marshal(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creator := processedTransaction.TransactionEnvelope.Payload.Header.SignatureHeader.Creator
+	if creator.Validation == nil || !creator.Validation.ChainValid || creator.Validation.ValidAtTimestamp == nil || !*creator.Validation.ValidAtTimestamp {
+		t.Errorf("the creator Validation = %+v, want a valid chain at the timestamp of the transaction", creator.Validation)
+	}
+	endorser := endorsedAction(t, processedTransaction).Endorsements[0].Endorser
+	if endorser.Validation == nil || endorser.Validation.ChainValid {
+		t.Errorf("the endorser Validation = %+v, want an invalid chain", endorser.Validation)
+	}
+}
+
+func TestClassifyIdentity(t *testing.T) {
+	org1 := newTestCA(t, "org1")
+	org2 := newTestCA(t, "org2")
+	admin := org1.signer(t, "Org1MSP", "admin1", "client")
+	adminSerializedIdentity := &msp.SerializedIdentity{}
+	err := adminSerializedIdentity.XXX_Unmarshal(admin.identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminCert, err := parseCertificate(adminSerializedIdentity.GetIdBytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeOUs := &NodeOUs{
+		Enable:              true,
+		ClientOUIdentifier:  &OUIdentifier{OrganizationalUnitIdentifier: "client"},
+		PeerOUIdentifier:    &OUIdentifier{OrganizationalUnitIdentifier: "peer", Certificate: org1.cert},
+		AdminOUIdentifier:   &OUIdentifier{OrganizationalUnitIdentifier: "admin"},
+		OrdererOUIdentifier: &OUIdentifier{OrganizationalUnitIdentifier: "orderer", Certificate: org2.cert},
+	}
+	msps := map[string]*MSP{
+		"Org1MSP":      {RootCerts: []*x509.Certificate{org1.cert}, Admins: []*x509.Certificate{adminCert}, NodeOUs: nodeOUs},
+		"NoNodeOUsMSP": org1.msp(),
+		"DisabledMSP":  {RootCerts: []*x509.Certificate{org1.cert}, NodeOUs: &NodeOUs{PeerOUIdentifier: &OUIdentifier{OrganizationalUnitIdentifier: "peer"}}},
+		"NoOrgMSP":     {RootCerts: []*x509.Certificate{newTestCA(t, "org3").cert}},
+	}
+	// the organization of a root CA without one is the Mspid
+	msps["NoOrgMSP"].RootCerts[0].Subject.Organization = nil
+
+	idemixIdentity := func(role msp.MSPRole_MSPRoleType) []byte {
+		ou := testMarshal(t, &msp.OrganizationUnit{MspIdentifier: "Org1IdemixMSP", OrganizationalUnitIdentifier: "department1"})
+		mspRole := testMarshal(t, &msp.MSPRole{MspIdentifier: "Org1IdemixMSP", Role: role})
+		return testIdentity(t, "Org1IdemixMSP", testMarshal(t, &msp.SerializedIdemixIdentity{NymX: []byte{1}, NymY: []byte{2}, Ou: ou, Role: mspRole}))
+	}
+
+	tests := []struct {
+		name     string
+		identity []byte
+		role     string
+		org      string
+	}{
+		{"client", org1.signer(t, "Org1MSP", "user1", "client").identity, RoleClient, "org1"},
+		{"peer", org1.signer(t, "Org1MSP", "peer0", "peer").identity, RolePeer, "org1"},
+		{"admin", org1.signer(t, "Org1MSP", "admin2", "admin").identity, RoleAdmin, "org1"},
+		{"admin certificate", admin.identity, RoleAdmin, "org1"},
+		{"orderer of another CA", org1.signer(t, "Org1MSP", "orderer0", "orderer").identity, "", "org1"},
+		{"peer of another CA", org2.signer(t, "Org1MSP", "peer0", "peer").identity, "", "org1"},
+		{"no node OU", org1.signer(t, "Org1MSP", "user2", "department1").identity, "", "org1"},
+		{"no NodeOUs", org1.signer(t, "NoNodeOUsMSP", "peer0", "peer").identity, RoleMember, "org1"},
+		{"disabled NodeOUs", org1.signer(t, "DisabledMSP", "peer0", "peer").identity, RoleMember, "org1"},
+		{"root CA without organization", org1.signer(t, "NoOrgMSP", "user1").identity, RoleMember, "NoOrgMSP"},
+		{"not a certificate", testIdentity(t, "Org1MSP", []byte("junk")), "", "org1"},
+		{"unknown MSP", org1.signer(t, "Org4MSP", "peer0", "peer").identity, "", ""},
+		{"Idemix member", idemixIdentity(msp.MSPRole_MEMBER), RoleMember, "Org1IdemixMSP"},
+		{"Idemix admin", idemixIdentity(msp.MSPRole_ADMIN), RoleAdmin, "Org1IdemixMSP"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serializedIdentity := &msp.SerializedIdentity{}
+			err := serializedIdentity.XXX_Unmarshal(test.identity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoder := &Decoder{MSPs: msps}
+			role, org := decoder.classifyIdentity(serializedIdentity)
+			if role != test.role || org != test.org {
+				t.Errorf("classifyIdentity() = %q, %q, want %q, %q", role, org, test.role, test.org)
+			}
+		})
+	}
+}
+
+func TestReadMSPs(t *testing.T) {
+	org1 := newTestCA(t, "org1")
+	admin := org1.issue(t, "admin1", &newTestKey(t).PublicKey, "client")
+	writeCertificate := func(file string, cert *x509.Certificate) {
+		t.Helper()
+		err := os.MkdirAll(filepath.Dir(file), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		config  string // the config.yaml, none when empty
+		nodeOUs *NodeOUs
+		err     bool
+	}{
+		{"without config.yaml", "", nil, false},
+		{"without NodeOUs", "OrganizationalUnitIdentifiers: []\n", nil, false},
+		{"with NodeOUs", `NodeOUs:
+  Enable: true
+  ClientOUIdentifier:
+    Certificate: cacerts/ca.pem
+    OrganizationalUnitIdentifier: client
+  PeerOUIdentifier:
+    OrganizationalUnitIdentifier: peer
+`, &NodeOUs{
+			Enable:             true,
+			ClientOUIdentifier: &OUIdentifier{OrganizationalUnitIdentifier: "client", Certificate: org1.cert},
+			PeerOUIdentifier:   &OUIdentifier{OrganizationalUnitIdentifier: "peer"},
+		}, false},
+		{"with a missing certificate", "NodeOUs:\n  Enable: true\n  PeerOUIdentifier:\n    Certificate: cacerts/missing.pem\n    OrganizationalUnitIdentifier: peer\n", nil, true},
+		{"not YAML", "NodeOUs: [\n", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeCertificate(filepath.Join(dir, "Org1MSP", "cacerts", "ca.pem"), org1.cert)
+			writeCertificate(filepath.Join(dir, "Org1MSP", "admincerts", "admin.pem"), admin)
+			if test.config != "" {
+				err := os.WriteFile(filepath.Join(dir, "Org1MSP", "config.yaml"), []byte(test.config), 0o644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			msps, err := ReadMSPs(dir)
+			if test.err {
+				if err == nil {
+					t.Error("ReadMSPs() has no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			org1MSP := msps["Org1MSP"]
+			if org1MSP == nil || len(org1MSP.RootCerts) != 1 || !org1MSP.RootCerts[0].Equal(org1.cert) {
+				t.Fatalf("ReadMSPs() = %v, want the CA of Org1MSP", msps)
+			}
+			if len(org1MSP.Admins) != 1 || !org1MSP.Admins[0].Equal(admin) {
+				t.Errorf("Admins = %v, want the admin certificate", org1MSP.Admins)
+			}
+			if !reflect.DeepEqual(org1MSP.NodeOUs, test.nodeOUs) {
+				t.Errorf("NodeOUs = %+v, want %+v", org1MSP.NodeOUs, test.nodeOUs)
+			}
+		})
+	}
+}
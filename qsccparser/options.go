@@ -0,0 +1,208 @@
+package qsccparser
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BytesEncoding is how a Decoder writes the bytes fields of the JSON output
+type BytesEncoding int
+
+const (
+	BytesBase64 BytesEncoding = iota // standard base64, like encoding/json does for []byte
+	BytesHex                         // lower case hex
+	BytesUTF8                        // the bytes as a string when they are printable UTF-8, standard base64 otherwise
+)
+
+// ParsedBytes is a bytes field. It marshals to a JSON string in the BytesEncoding of the Decoder, or to
+// {"Bytes": "...", "Length": 1234} when it was cut to the MaxValueSize of the Decoder.
+type ParsedBytes struct {
+	Bytes  []byte // the first MaxValueSize bytes of the field
+	Length int    // length of the whole field
+
+	encoding BytesEncoding
+}
+
+func (pb ParsedBytes) MarshalJSON() ([]byte, error) {
+	if pb.Bytes == nil {
+		return []byte("null"), nil
+	}
+	if len(pb.Bytes) < pb.Length {
+		return json.Marshal(struct {
+			Bytes  string
+			Length int
+		}{pb.encode(), pb.Length})
+	}
+	return json.Marshal(pb.encode())
+}
+
+func (pb ParsedBytes) encode() string {
+	switch pb.encoding {
+	case BytesHex:
+		return hex.EncodeToString(pb.Bytes)
+	case BytesUTF8:
+		if isPrintable(pb.Bytes) {
+			return string(pb.Bytes)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(pb.Bytes)
+}
+
+func isPrintable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParsedTimestamp is a google.protobuf.Timestamp field. It marshals to {"seconds": ..., "nanos": ...},
+// or to an RFC 3339 string for a Decoder with RFC3339Timestamps.
+type ParsedTimestamp struct {
+	Timestamp *timestamppb.Timestamp
+
+	rfc3339 bool
+}
+
+func (pt ParsedTimestamp) MarshalJSON() ([]byte, error) {
+	if pt.rfc3339 && pt.Timestamp != nil {
+		return json.Marshal(pt.Timestamp.AsTime().Format(time.RFC3339Nano))
+	}
+	return json.Marshal(pt.Timestamp)
+}
+
+func (d *Decoder) bytes(data []byte) ParsedBytes {
+	decoded := ParsedBytes{Bytes: data, Length: len(data)}
+	if d == nil {
+		return decoded
+	}
+	decoded.encoding = d.BytesEncoding
+	if d.MaxValueSize > 0 && len(data) > d.MaxValueSize {
+		decoded.Bytes = data[:d.MaxValueSize]
+	}
+	return decoded
+}
+
+func (d *Decoder) bytesList(list [][]byte) []ParsedBytes {
+	if list == nil {
+		return nil
+	}
+	decoded := []ParsedBytes{}
+	for _, data := range list {
+		decoded = append(decoded, d.bytes(data))
+	}
+	return decoded
+}
+
+func (d *Decoder) bytesMap(m map[string][]byte) map[string]ParsedBytes {
+	if m == nil {
+		return nil
+	}
+	decoded := map[string]ParsedBytes{}
+	for key, data := range m {
+		decoded[key] = d.bytes(data)
+	}
+	return decoded
+}
+
+func (d *Decoder) timestamp(timestamp *timestamppb.Timestamp) ParsedTimestamp {
+	return ParsedTimestamp{Timestamp: timestamp, rfc3339: d != nil && d.RFC3339Timestamps}
+}
+
+func (d *Decoder) skipCertificates() bool {
+	return d != nil && d.SkipCertificates
+}
+
+// nested returns the decoder for the nodes below the node being decoded, and false when that node is itself more
+// than MaxDepth nodes deep, so that its Decode* method leaves it empty without decoding its nested messages.
+func (d *Decoder) nested() (*Decoder, bool) {
+	if d == nil || d.MaxDepth <= 0 {
+		return d, true
+	}
+	if d.depth > d.MaxDepth {
+		return d, false
+	}
+	nestedDecoder := *d
+	nestedDecoder.depth++
+	return &nestedDecoder, true
+}
+
+// cutDepth sets to nil the nodes of the tree below root that are more than MaxDepth nodes deep: the ones nested
+// left empty, and the ones a Decode* method builds itself without a Decode* method of their own. What reads the
+// tree afterwards has to expect nil nodes.
+func (d *Decoder) cutDepth(root interface{}) {
+	if d != nil && d.MaxDepth > 0 {
+		cutNodes(reflect.ValueOf(root), 0, d.MaxDepth)
+	}
+}
+
+func cutNodes(value reflect.Value, depth int, maxDepth int) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			cutNodes(value.Elem(), depth, maxDepth)
+		}
+	case reflect.Struct:
+		if !isNode(value.Type()) {
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !value.Type().Field(i).IsExported() || !holdsNode(field) {
+				continue
+			}
+			if depth+1 > maxDepth && field.CanSet() {
+				field.Set(reflect.Zero(field.Type()))
+				continue
+			}
+			cutNodes(field, depth+1, maxDepth)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			cutNodes(value.Index(i), depth, maxDepth)
+		}
+	case reflect.Map:
+		// map values cannot be set in place, the nodes they point to can
+		for _, key := range value.MapKeys() {
+			cutNodes(value.MapIndex(key), depth, maxDepth)
+		}
+	}
+}
+
+// holdsNode reports whether value is or refers to a node of the tree
+func holdsNode(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return !value.IsNil() && holdsNode(value.Elem())
+	case reflect.Struct:
+		return isNode(value.Type())
+	case reflect.Slice, reflect.Map:
+		element := value.Type().Elem()
+		for element.Kind() == reflect.Ptr {
+			element = element.Elem()
+		}
+		return element.Kind() == reflect.Interface || isNode(element)
+	}
+	return false
+}
+
+// isNode reports whether t is one of the Parsed* nodes, rather than a value like ParsedEnum or ParsedBytes
+func isNode(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(ParsedEnum{}), reflect.TypeOf(ParsedBytes{}), reflect.TypeOf(ParsedTimestamp{}), reflect.TypeOf(ParsedFailure{}):
+		return false
+	}
+	return t.Kind() == reflect.Struct && strings.HasPrefix(t.Name(), "Parsed")
+}
@@ -0,0 +1,186 @@
+package qsccparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMaxDepth(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	transaction := (&testTransaction{creator: creator, endorsers: []*testSigner{creator}}).marshal(t)
+	// the malformed chaincode action payload is TransactionEnvelope.Payload.Data.Actions[0].Payload,
+	// unmarshalled by Actions[0], 4 nodes deep
+	malformed := (&testTransaction{creator: creator, chaincodeActionPayload: []byte{0x0a, 0xff}}).marshal(t)
+
+	tests := []struct {
+		maxDepth      int
+		envelope      bool // whether TransactionEnvelope is kept
+		payload       bool // whether TransactionEnvelope.Payload is kept
+		header        bool // whether TransactionEnvelope.Payload.Header is kept
+		channelHeader bool // whether TransactionEnvelope.Payload.Header.ChannelHeader is decoded
+		warnings      int  // of the malformed transaction
+	}{
+		{0, true, true, true, true, 1},
+		{1, true, false, false, false, 0},
+		{2, true, true, false, false, 0},
+		{3, true, true, true, false, 0},
+		{4, true, true, true, true, 1},
+	}
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.maxDepth), func(t *testing.T) {
+			handler := &recordingHandler{level: slog.LevelDebug}
+			decoder := &Decoder{MaxDepth: test.maxDepth, Logger: slog.New(handler)}
+			processedTransaction, err := decoder.DecodeTransaction(transaction)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			envelope := processedTransaction.TransactionEnvelope
+			if (envelope != nil) != test.envelope {
+				t.Fatalf("TransactionEnvelope = %+v", envelope)
+			}
+			if envelope == nil {
+				return
+			}
+			if (envelope.Payload != nil) != test.payload {
+				t.Fatalf("Payload = %+v", envelope.Payload)
+			}
+			if envelope.Payload != nil && (envelope.Payload.Header != nil) != test.header {
+				t.Errorf("Header = %+v", envelope.Payload.Header)
+			}
+			// a node past MaxDepth is not decoded at all, rather than decoded and dropped
+			if decoded := handler.count(slog.LevelDebug, "DecodedChannelHeader") > 0; decoded != test.channelHeader {
+				t.Errorf("ChannelHeader decoded = %v, want %v", decoded, test.channelHeader)
+			}
+
+			lenientDecoder := &Decoder{MaxDepth: test.maxDepth, Lenient: true}
+			processedTransaction, _ = lenientDecoder.DecodeTransaction(malformed)
+			if len(processedTransaction.Warnings) != test.warnings {
+				t.Errorf("Warnings = %v, want %d", processedTransaction.Warnings, test.warnings)
+			}
+		})
+	}
+}
+
+func TestMaxDepthOtherHeaderTypes(t *testing.T) {
+	// the payload data is decoded by the type of the channel header, even when the decoded one is cut
+	for _, headerType := range []common.HeaderType{common.HeaderType_MESSAGE, common.HeaderType_CONFIG, common.HeaderType_ORDERER_TRANSACTION, HeaderType_PEER_ADMIN_OPERATION} {
+		transaction := testMarshal(t, &peer.ProcessedTransaction{TransactionEnvelope: testEnvelope(t, headerType, []byte("data"))})
+		for maxDepth := 1; maxDepth <= 4; maxDepth++ {
+			t.Run(fmt.Sprintf("%s/%d", headerType, maxDepth), func(t *testing.T) {
+				processedTransaction, err := (&Decoder{MaxDepth: maxDepth, Lenient: true}).DecodeTransaction(transaction)
+				if processedTransaction == nil {
+					t.Fatalf("DecodeTransaction() = nil, %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestMaxValueSize(t *testing.T) {
+	tests := []struct {
+		maxValueSize int
+		data         []byte
+		json         string
+	}{
+		{0, []byte("abcdef"), `"YWJjZGVm"`},
+		{6, []byte("abcdef"), `"YWJjZGVm"`},
+		{3, []byte("abcdef"), `{"Bytes":"YWJj","Length":6}`},
+		{3, []byte{}, `""`},
+		{3, nil, `null`},
+	}
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			decoder := &Decoder{MaxValueSize: test.maxValueSize}
+			data, err := json.Marshal(decoder.bytes(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, test.json)
+			}
+		})
+	}
+}
+
+func TestBytesEncoding(t *testing.T) {
+	tests := []struct {
+		encoding BytesEncoding
+		data     []byte
+		json     string
+	}{
+		{BytesBase64, []byte("CreateAsset"), `"Q3JlYXRlQXNzZXQ="`},
+		{BytesHex, []byte("CreateAsset"), `"4372656174654173736574"`},
+		{BytesUTF8, []byte("CreateAsset"), `"CreateAsset"`},
+		{BytesUTF8, []byte("{\"id\": \"asset1\"}\n"), `"{\"id\": \"asset1\"}\n"`},
+		{BytesUTF8, []byte{0x00, 0x01, 0xff}, `"AAH/"`},
+		{BytesUTF8, []byte("tab\x00"), `"dGFiAA=="`},
+		{BytesHex, []byte{}, `""`},
+	}
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			decoder := &Decoder{BytesEncoding: test.encoding}
+			data, err := json.Marshal(decoder.bytes(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, test.json)
+			}
+		})
+	}
+}
+
+func TestRFC3339Timestamps(t *testing.T) {
+	timestamp := timestamppb.New(time.Date(2023, 5, 17, 8, 30, 1, 250000000, time.UTC))
+	tests := []struct {
+		decoder   *Decoder
+		timestamp *timestamppb.Timestamp
+		json      string
+	}{
+		{nil, timestamp, `{"seconds":1684312201,"nanos":250000000}`},
+		{&Decoder{}, timestamp, `{"seconds":1684312201,"nanos":250000000}`},
+		{&Decoder{RFC3339Timestamps: true}, timestamp, `"2023-05-17T08:30:01.25Z"`},
+		{&Decoder{RFC3339Timestamps: true}, nil, `null`},
+	}
+	for _, test := range tests {
+		t.Run(test.json, func(t *testing.T) {
+			data, err := json.Marshal(test.decoder.timestamp(test.timestamp))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, test.json)
+			}
+		})
+	}
+
+	t.Run("ChannelHeader", func(t *testing.T) {
+		ca := newTestCA(t, "org1")
+		transaction := (&testTransaction{creator: ca.signer(t, "Org1MSP", "user1")}).marshal(t)
+		processedTransaction, err := (&Decoder{RFC3339Timestamps: true}).DecodeTransaction(transaction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(processedTransaction.TransactionEnvelope.Payload.Header.ChannelHeader.Timestamp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rfc3339 string
+		if json.Unmarshal(data, &rfc3339) != nil {
+			t.Fatalf("Timestamp = %s, want an RFC 3339 string", data)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, rfc3339); err != nil {
+			t.Error(err)
+		}
+	})
+}
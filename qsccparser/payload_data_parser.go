@@ -0,0 +1,149 @@
+package qsccparser
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// HeaderType_PEER_ADMIN_OPERATION was dropped from common.HeaderType after Fabric 1.4,
+// but it can still be found in the ledgers of channels created back then
+const HeaderType_PEER_ADMIN_OPERATION common.HeaderType = 8
+
+type ParsedRawPayloadData struct {
+	// common.Payload.Data of a header type without a known message
+	Type ParsedEnum  //common.HeaderType of the payload
+	Data ParsedBytes //func (*common.Payload).GetData() []byte
+}
+
+func (drpd *ParsedRawPayloadData) DecodeRawPayloadData(decoder *Decoder, headerType int32, data []byte) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	drpd.Type = headerTypeEnum(headerType)
+	drpd.Data = decoder.bytes(data)
+
+	logger.Debug("DecodedRawPayloadData", "value", drpd)
+
+	return nil
+}
+
+type ParsedAdminOperation struct {
+	// peer.AdminOperation of Fabric 1.4, which fabric-protos-go no longer ships
+	LogLevelRequest *ParsedLogLevelRequest // field 1, the only member of the content oneof
+	Failures        []*ParsedFailure       `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dao *ParsedAdminOperation) DecodeAdminOperation(decoder *Decoder, adminOperation []byte) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	input := adminOperation
+
+	for len(adminOperation) > 0 {
+		number, wireType, n := protowire.ConsumeTag(adminOperation)
+		if n < 0 {
+			err := protowire.ParseError(n)
+			logger.Warn("cannot decode", "err", err)
+			return decoder.fail(&dao.Failures, decodeError("", "protos.AdminOperation", input, err))
+		}
+		adminOperation = adminOperation[n:]
+
+		if number == 1 && wireType == protowire.BytesType {
+			logLevelRequest, n := protowire.ConsumeBytes(adminOperation)
+			if n < 0 {
+				err := protowire.ParseError(n)
+				logger.Warn("cannot decode", "err", err)
+				return decoder.fail(&dao.Failures, decodeError("", "protos.AdminOperation", input, err))
+			}
+			adminOperation = adminOperation[n:]
+
+			decodedLogLevelRequest := &ParsedLogLevelRequest{}
+			err := decodedLogLevelRequest.DecodeLogLevelRequest(decoder, logLevelRequest)
+			if err != nil {
+				logger.Debug("cannot decode", "err", err)
+				return prefixPath("LogLevelRequest", err)
+			}
+			dao.LogLevelRequest = decodedLogLevelRequest
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(number, wireType, adminOperation)
+		if n < 0 {
+			err := fmt.Errorf("field %d of AdminOperation: %w", number, protowire.ParseError(n))
+			logger.Warn("cannot decode", "err", err)
+			return decoder.fail(&dao.Failures, decodeError("", "protos.AdminOperation", input, err))
+		}
+		adminOperation = adminOperation[n:]
+	}
+
+	logger.Debug("DecodedAdminOperation", "value", dao)
+
+	return nil
+}
+
+type ParsedLogLevelRequest struct {
+	// peer.LogLevelRequest of Fabric 1.4
+	LogModule string           // field 1
+	LogLevel  string           // field 2
+	Failures  []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dllr *ParsedLogLevelRequest) DecodeLogLevelRequest(decoder *Decoder, logLevelRequest []byte) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	input := logLevelRequest
+
+	for len(logLevelRequest) > 0 {
+		number, wireType, n := protowire.ConsumeTag(logLevelRequest)
+		if n < 0 {
+			err := protowire.ParseError(n)
+			logger.Warn("cannot decode", "err", err)
+			return decoder.fail(&dllr.Failures, decodeError("", "protos.LogLevelRequest", input, err))
+		}
+		logLevelRequest = logLevelRequest[n:]
+
+		if (number == 1 || number == 2) && wireType == protowire.BytesType {
+			value, n := protowire.ConsumeString(logLevelRequest)
+			if n < 0 {
+				err := protowire.ParseError(n)
+				logger.Warn("cannot decode", "err", err)
+				return decoder.fail(&dllr.Failures, decodeError("", "protos.LogLevelRequest", input, err))
+			}
+			logLevelRequest = logLevelRequest[n:]
+
+			if number == 1 {
+				dllr.LogModule = value
+			} else {
+				dllr.LogLevel = value
+			}
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(number, wireType, logLevelRequest)
+		if n < 0 {
+			err := fmt.Errorf("field %d of LogLevelRequest: %w", number, protowire.ParseError(n))
+			logger.Warn("cannot decode", "err", err)
+			return decoder.fail(&dllr.Failures, decodeError("", "protos.LogLevelRequest", input, err))
+		}
+		logLevelRequest = logLevelRequest[n:]
+	}
+
+	logger.Debug("DecodedLogLevelRequest", "value", dllr)
+
+	return nil
+}
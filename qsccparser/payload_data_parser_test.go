@@ -0,0 +1,110 @@
+package qsccparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestDecodeOrdererTransaction(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	// the genesis block of the system channel carries the config of a new channel in an ORDERER_TRANSACTION
+	configEnvelope := testMarshal(t, &common.ConfigEnvelope{Config: &common.Config{ChannelGroup: testChannelGroup(t, ca)}})
+	configTransaction := testMarshal(t, testEnvelope(t, common.HeaderType_CONFIG, configEnvelope))
+	block := testBlock(t, 0, nil, testEnvelope(t, common.HeaderType_ORDERER_TRANSACTION, configTransaction))
+
+	decodedBlock, err := DecodeBlock(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := decodedBlock.Data.Data[0].Payload
+	if payload.Header.ChannelHeader.Type.Name != "ORDERER_TRANSACTION" {
+		t.Errorf("ChannelHeader.Type = %v", payload.Header.ChannelHeader.Type)
+	}
+	transactionEnvelope, ok := payload.Data.(*ParsedTransactionEnvelope)
+	if !ok {
+		t.Fatalf("Payload.Data = %T, want the wrapped envelope", payload.Data)
+	}
+	if _, ok := transactionEnvelope.Payload.Data.(*ParsedConfigEnvelope); !ok || transactionEnvelope.Payload.Header.ChannelHeader.Type.Name != "CONFIG" {
+		t.Errorf("the wrapped Payload = %+v, want the config transaction", transactionEnvelope.Payload)
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		block := testBlock(t, 0, nil, testEnvelope(t, common.HeaderType_ORDERER_TRANSACTION, []byte{0x0a, 0xff}))
+		_, err := DecodeBlock(block)
+		if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "Data.Data[0].Payload.Data" || decodeErr.Message != "common.Envelope" {
+			t.Errorf("DecodeBlock() = %v, want the DecodeError of the wrapped envelope", err)
+		}
+	})
+}
+
+func TestDecodeAdminOperation(t *testing.T) {
+	var logLevelRequest []byte
+	logLevelRequest = protowire.AppendTag(logLevelRequest, 1, protowire.BytesType)
+	logLevelRequest = protowire.AppendString(logLevelRequest, "gossip")
+	logLevelRequest = protowire.AppendTag(logLevelRequest, 2, protowire.BytesType)
+	logLevelRequest = protowire.AppendString(logLevelRequest, "DEBUG")
+	var adminOperation []byte
+	adminOperation = protowire.AppendTag(adminOperation, 1, protowire.BytesType)
+	adminOperation = protowire.AppendBytes(adminOperation, logLevelRequest)
+	// fields it does not know are skipped
+	adminOperation = protowire.AppendTag(adminOperation, 5, protowire.VarintType)
+	adminOperation = protowire.AppendVarint(adminOperation, 1)
+
+	decodedBlock, err := DecodeBlock(testBlock(t, 3, nil, testEnvelope(t, HeaderType_PEER_ADMIN_OPERATION, adminOperation)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := decodedBlock.Data.Data[0].Payload
+	if payload.Header.ChannelHeader.Type != (ParsedEnum{8, "PEER_ADMIN_OPERATION"}) {
+		t.Errorf("ChannelHeader.Type = %v", payload.Header.ChannelHeader.Type)
+	}
+	decodedAdminOperation, ok := payload.Data.(*ParsedAdminOperation)
+	if !ok {
+		t.Fatalf("Payload.Data = %T, want the AdminOperation", payload.Data)
+	}
+	if want := (&ParsedLogLevelRequest{LogModule: "gossip", LogLevel: "DEBUG"}); !reflect.DeepEqual(decodedAdminOperation.LogLevelRequest, want) {
+		t.Errorf("LogLevelRequest = %+v, want %+v", decodedAdminOperation.LogLevelRequest, want)
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		block := testBlock(t, 3, nil, testEnvelope(t, HeaderType_PEER_ADMIN_OPERATION, adminOperation[:len(adminOperation)-4]))
+		_, err := DecodeBlock(block)
+		if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "Data.Data[0].Payload.Data" || decodeErr.Message != "protos.AdminOperation" {
+			t.Errorf("DecodeBlock() = %v, want the DecodeError of the AdminOperation", err)
+		}
+		decodedBlock, _ := (&Decoder{Lenient: true}).DecodeBlock(block)
+		decodedAdminOperation, ok := decodedBlock.Data.Data[0].Payload.Data.(*ParsedAdminOperation)
+		if !ok || len(decodedAdminOperation.Failures) != 1 {
+			t.Errorf("lenient Payload.Data = %+v, want the AdminOperation with the failure", decodedBlock.Data.Data[0].Payload.Data)
+		}
+	})
+}
+
+func TestDecodeRawPayloadData(t *testing.T) {
+	tests := []struct {
+		headerType common.HeaderType
+		name       string
+	}{
+		{common.HeaderType_MESSAGE, "MESSAGE"},
+		{common.HeaderType_DELIVER_SEEK_INFO, "DELIVER_SEEK_INFO"},
+		{common.HeaderType_CHAINCODE_PACKAGE, "CHAINCODE_PACKAGE"},
+		// types of later versions are kept with their number
+		{42, "UNKNOWN_HEADER_TYPE_42"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decodedBlock, err := DecodeBlock(testBlock(t, 3, nil, testEnvelope(t, test.headerType, []byte("data"))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// rather than a half decoded peer.Transaction
+			want := &ParsedRawPayloadData{Type: ParsedEnum{int32(test.headerType), test.name}, Data: ParsedBytes{Bytes: []byte("data"), Length: 4}}
+			if data := decodedBlock.Data.Data[0].Payload.Data; !reflect.DeepEqual(data, want) {
+				t.Errorf("Payload.Data = %+v, want %+v", data, want)
+			}
+		})
+	}
+}
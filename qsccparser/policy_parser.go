@@ -0,0 +1,328 @@
+package qsccparser
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+type ParsedPolicy struct {
+	// *common.Policy
+	Type     string           //func (*common.Policy).GetType() int32
+	Value    interface{}      //func (*common.Policy).GetValue() []byte, decoded according to Type
+	Failures []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dp *ParsedPolicy) DecodePolicy(decoder *Decoder, policy *common.Policy) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dp.Type = common.Policy_PolicyType(policy.GetType()).String()
+
+	switch common.Policy_PolicyType(policy.GetType()) {
+	case common.Policy_SIGNATURE:
+		signaturePolicyEnvelope := &common.SignaturePolicyEnvelope{}
+		err := signaturePolicyEnvelope.XXX_Unmarshal(policy.GetValue())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dp.Failures, unmarshalError("Value", signaturePolicyEnvelope, policy.GetValue(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedSignaturePolicyEnvelope := &ParsedSignaturePolicyEnvelope{}
+		err = decodedSignaturePolicyEnvelope.DecodeSignaturePolicyEnvelope(decoder, signaturePolicyEnvelope)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Value", err)
+		}
+		dp.Value = decodedSignaturePolicyEnvelope
+	case common.Policy_IMPLICIT_META:
+		implicitMetaPolicy := &common.ImplicitMetaPolicy{}
+		err := implicitMetaPolicy.XXX_Unmarshal(policy.GetValue())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dp.Failures, unmarshalError("Value", implicitMetaPolicy, policy.GetValue(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedImplicitMetaPolicy := &ParsedImplicitMetaPolicy{}
+		err = decodedImplicitMetaPolicy.DecodeImplicitMetaPolicy(decoder, implicitMetaPolicy)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Value", err)
+		}
+		dp.Value = decodedImplicitMetaPolicy
+	default:
+		dp.Value = decoder.bytes(policy.GetValue())
+	}
+
+	logger.Debug("DecodedPolicy", "value", dp)
+
+	return nil
+}
+
+type ParsedSignaturePolicyEnvelope struct {
+	// *common.SignaturePolicyEnvelope
+	Version    int32                  //func (*common.SignaturePolicyEnvelope).GetVersion() int32
+	Rule       *ParsedSignaturePolicy //func (*common.SignaturePolicyEnvelope).GetRule() *common.SignaturePolicy
+	Identities []*ParsedMSPPrincipal  //func (*common.SignaturePolicyEnvelope).GetIdentities() []*msp.MSPPrincipal
+}
+
+func (dspe *ParsedSignaturePolicyEnvelope) DecodeSignaturePolicyEnvelope(decoder *Decoder, signaturePolicyEnvelope *common.SignaturePolicyEnvelope) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dspe.Version = signaturePolicyEnvelope.GetVersion()
+
+	decodedSignaturePolicy := &ParsedSignaturePolicy{}
+	err := decodedSignaturePolicy.DecodeSignaturePolicy(decoder, signaturePolicyEnvelope.GetRule())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Rule", err)
+	}
+	dspe.Rule = decodedSignaturePolicy
+
+	decodedMSPPrincipals := []*ParsedMSPPrincipal{}
+	for i, mspPrincipal := range signaturePolicyEnvelope.GetIdentities() {
+		decodedMSPPrincipal := &ParsedMSPPrincipal{}
+		err := decodedMSPPrincipal.DecodeMSPPrincipal(decoder, mspPrincipal)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Identities[%d]", i), err)
+		}
+		decodedMSPPrincipals = append(decodedMSPPrincipals, decodedMSPPrincipal)
+	}
+	dspe.Identities = decodedMSPPrincipals
+
+	logger.Debug("DecodedSignaturePolicyEnvelope", "value", dspe)
+
+	return nil
+}
+
+type ParsedSignaturePolicy struct {
+	// *common.SignaturePolicy, exactly one of SignedBy and NOutOf is set
+	SignedBy *int32                       //func (*common.SignaturePolicy).GetSignedBy() int32
+	NOutOf   *ParsedSignaturePolicyNOutOf //func (*common.SignaturePolicy).GetNOutOf() *common.SignaturePolicy_NOutOf
+}
+
+func (dsp *ParsedSignaturePolicy) DecodeSignaturePolicy(decoder *Decoder, signaturePolicy *common.SignaturePolicy) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	switch signaturePolicy.GetType().(type) {
+	case *common.SignaturePolicy_SignedBy:
+		signedBy := signaturePolicy.GetSignedBy()
+		dsp.SignedBy = &signedBy
+	case *common.SignaturePolicy_NOutOf_:
+		decodedSignaturePolicyNOutOf := &ParsedSignaturePolicyNOutOf{}
+		err := decodedSignaturePolicyNOutOf.DecodeSignaturePolicyNOutOf(decoder, signaturePolicy.GetNOutOf())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("NOutOf", err)
+		}
+		dsp.NOutOf = decodedSignaturePolicyNOutOf
+	}
+
+	logger.Debug("DecodedSignaturePolicy", "value", dsp)
+
+	return nil
+}
+
+type ParsedSignaturePolicyNOutOf struct {
+	// *common.SignaturePolicy_NOutOf
+	N     int32                    //func (*common.SignaturePolicy_NOutOf).GetN() int32
+	Rules []*ParsedSignaturePolicy //func (*common.SignaturePolicy_NOutOf).GetRules() []*common.SignaturePolicy
+}
+
+func (dspno *ParsedSignaturePolicyNOutOf) DecodeSignaturePolicyNOutOf(decoder *Decoder, signaturePolicyNOutOf *common.SignaturePolicy_NOutOf) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dspno.N = signaturePolicyNOutOf.GetN()
+
+	decodedSignaturePolicies := []*ParsedSignaturePolicy{}
+	for i, signaturePolicy := range signaturePolicyNOutOf.GetRules() {
+		decodedSignaturePolicy := &ParsedSignaturePolicy{}
+		err := decodedSignaturePolicy.DecodeSignaturePolicy(decoder, signaturePolicy)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Rules[%d]", i), err)
+		}
+		decodedSignaturePolicies = append(decodedSignaturePolicies, decodedSignaturePolicy)
+	}
+	dspno.Rules = decodedSignaturePolicies
+
+	logger.Debug("DecodedSignaturePolicyNOutOf", "value", dspno)
+
+	return nil
+}
+
+type ParsedImplicitMetaPolicy struct {
+	// *common.ImplicitMetaPolicy
+	SubPolicy string //func (*common.ImplicitMetaPolicy).GetSubPolicy() string
+	Rule      string //func (*common.ImplicitMetaPolicy).GetRule() common.ImplicitMetaPolicy_Rule
+}
+
+func (dimp *ParsedImplicitMetaPolicy) DecodeImplicitMetaPolicy(decoder *Decoder, implicitMetaPolicy *common.ImplicitMetaPolicy) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dimp.SubPolicy = implicitMetaPolicy.GetSubPolicy()
+	dimp.Rule = implicitMetaPolicy.GetRule().String()
+
+	logger.Debug("DecodedImplicitMetaPolicy", "value", dimp)
+
+	return nil
+}
+
+type ParsedMSPPrincipal struct {
+	// *msp.MSPPrincipal
+	PrincipalClassification string           //func (*msp.MSPPrincipal).GetPrincipalClassification() msp.MSPPrincipal_Classification
+	Principal               interface{}      //func (*msp.MSPPrincipal).GetPrincipal() []byte, decoded according to PrincipalClassification
+	Failures                []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dmp *ParsedMSPPrincipal) DecodeMSPPrincipal(decoder *Decoder, mspPrincipal *msp.MSPPrincipal) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dmp.PrincipalClassification = mspPrincipal.GetPrincipalClassification().String()
+
+	switch mspPrincipal.GetPrincipalClassification() {
+	case msp.MSPPrincipal_ROLE:
+		mspRole := &msp.MSPRole{}
+		err := mspRole.XXX_Unmarshal(mspPrincipal.GetPrincipal())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dmp.Failures, unmarshalError("Principal", mspRole, mspPrincipal.GetPrincipal(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedMSPRole := &ParsedMSPRole{}
+		err = decodedMSPRole.DecodeMSPRole(decoder, mspRole)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Principal", err)
+		}
+		dmp.Principal = decodedMSPRole
+	case msp.MSPPrincipal_ORGANIZATION_UNIT:
+		organizationUnit := &msp.OrganizationUnit{}
+		err := organizationUnit.XXX_Unmarshal(mspPrincipal.GetPrincipal())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dmp.Failures, unmarshalError("Principal", organizationUnit, mspPrincipal.GetPrincipal(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedOrganizationUnit := &ParsedOrganizationUnit{}
+		err = decodedOrganizationUnit.DecodeOrganizationUnit(decoder, organizationUnit)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Principal", err)
+		}
+		dmp.Principal = decodedOrganizationUnit
+	case msp.MSPPrincipal_IDENTITY:
+		serializedIdentity := &msp.SerializedIdentity{}
+		err := serializedIdentity.XXX_Unmarshal(mspPrincipal.GetPrincipal())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dmp.Failures, unmarshalError("Principal", serializedIdentity, mspPrincipal.GetPrincipal(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedSerializedIdentity := &ParsedSerializedIdentity{}
+		err = decodedSerializedIdentity.DecodeSerializedIdentity(decoder, serializedIdentity)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Principal", err)
+		}
+		dmp.Principal = decodedSerializedIdentity
+	default:
+		dmp.Principal = decoder.bytes(mspPrincipal.GetPrincipal())
+	}
+
+	logger.Debug("DecodedMSPPrincipal", "value", dmp)
+
+	return nil
+}
+
+type ParsedMSPRole struct {
+	// *msp.MSPRole
+	MspIdentifier string //func (*msp.MSPRole).GetMspIdentifier() string
+	Role          string //func (*msp.MSPRole).GetRole() msp.MSPRole_MSPRoleType
+}
+
+func (dmr *ParsedMSPRole) DecodeMSPRole(decoder *Decoder, mspRole *msp.MSPRole) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dmr.MspIdentifier = mspRole.GetMspIdentifier()
+	dmr.Role = mspRole.GetRole().String()
+
+	logger.Debug("DecodedMSPRole", "value", dmr)
+
+	return nil
+}
+
+type ParsedOrganizationUnit struct {
+	// *msp.OrganizationUnit
+	MspIdentifier                string      //func (*msp.OrganizationUnit).GetMspIdentifier() string
+	OrganizationalUnitIdentifier string      //func (*msp.OrganizationUnit).GetOrganizationalUnitIdentifier() string
+	CertifiersIdentifier         ParsedBytes //func (*msp.OrganizationUnit).GetCertifiersIdentifier() []byte
+}
+
+func (dou *ParsedOrganizationUnit) DecodeOrganizationUnit(decoder *Decoder, organizationUnit *msp.OrganizationUnit) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dou.MspIdentifier = organizationUnit.GetMspIdentifier()
+	dou.OrganizationalUnitIdentifier = organizationUnit.GetOrganizationalUnitIdentifier()
+	dou.CertifiersIdentifier = decoder.bytes(organizationUnit.GetCertifiersIdentifier())
+
+	logger.Debug("DecodedOrganizationUnit", "value", dou)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package qsccparser
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+)
+
+type ParsedPublicKey struct {
+	// the public key of *x509.Certificate
+	Algorithm         string      // (*x509.Certificate).PublicKeyAlgorithm() x509.PublicKeyAlgorithm
+	Curve             string      `json:",omitempty"` // of an ECDSA key, e.g. P-256
+	BitSize           int         // of the curve of an ECDSA key, of the modulus of an RSA key
+	PEM               string      // the SubjectPublicKeyInfo as a PUBLIC KEY PEM block
+	DER               ParsedBytes // (*x509.Certificate).RawSubjectPublicKeyInfo() []byte
+	JWK               *ParsedJWK  `json:",omitempty"` // of ECDSA, RSA and Ed25519 keys
+	SHA256Fingerprint string      // hex of the SHA-256 of DER, like `openssl pkey -pubin -outform DER | sha256sum`
+}
+
+// ParsedJWK is a public key as a JSON Web Key (RFC 7517), with its member names, and its fields in base64url without padding
+type ParsedJWK struct {
+	Kty string `json:"kty"`           // EC, RSA or OKP
+	Crv string `json:"crv,omitempty"` // P-256, P-384, P-521 or Ed25519
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+func (dpk *ParsedPublicKey) DecodePublicKey(decoder *Decoder, cert *x509.Certificate) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dpk.Algorithm = cert.PublicKeyAlgorithm.String()
+	dpk.PEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: cert.RawSubjectPublicKeyInfo}))
+	dpk.DER = decoder.bytes(cert.RawSubjectPublicKeyInfo)
+	fingerprint := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	dpk.SHA256Fingerprint = hex.EncodeToString(fingerprint[:])
+
+	switch publicKey := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		params := publicKey.Curve.Params()
+		dpk.Curve = params.Name
+		dpk.BitSize = params.BitSize
+		size := (params.BitSize + 7) / 8
+		dpk.JWK = &ParsedJWK{
+			Kty: "EC",
+			Crv: params.Name,
+			X:   base64URL(publicKey.X.FillBytes(make([]byte, size))),
+			Y:   base64URL(publicKey.Y.FillBytes(make([]byte, size))),
+		}
+	case *rsa.PublicKey:
+		dpk.BitSize = publicKey.N.BitLen()
+		dpk.JWK = &ParsedJWK{
+			Kty: "RSA",
+			N:   base64URL(publicKey.N.Bytes()),
+			E:   base64URL(big.NewInt(int64(publicKey.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		dpk.BitSize = len(publicKey) * 8
+		dpk.JWK = &ParsedJWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64URL(publicKey),
+		}
+	}
+
+	logger.Debug("DecodedPublicKey", "value", dpk)
+
+	return nil
+}
+
+func base64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,138 @@
+package qsccparser
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDecodePublicKey(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		publicKey crypto.PublicKey
+		algorithm string
+		curve     string
+		bitSize   int
+		kty       string
+	}{
+		{"ECDSA P-256", &newTestKey(t).PublicKey, "ECDSA", "P-256", 256, "EC"},
+		{"ECDSA P-384", &p384.PublicKey, "ECDSA", "P-384", 384, "EC"},
+		{"RSA", &rsaKey.PublicKey, "RSA", "", 2048, "RSA"},
+		{"Ed25519", ed25519Key, "Ed25519", "", 256, "OKP"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cert := ca.issue(t, "peer0", test.publicKey, "peer")
+			decodedIdBytes := &ParsedIdBytes{}
+			err := decodedIdBytes.DecodeIdBytes(nil, cert.Raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			publicKey := decodedIdBytes.PublicKey
+			if publicKey.Algorithm != test.algorithm || publicKey.Curve != test.curve || publicKey.BitSize != test.bitSize {
+				t.Errorf("PublicKey Algorithm = %s, Curve = %q, BitSize = %d, want %s, %q, %d", publicKey.Algorithm, publicKey.Curve, publicKey.BitSize, test.algorithm, test.curve, test.bitSize)
+			}
+
+			// the PEM and the DER are the SubjectPublicKeyInfo of the certificate
+			block, _ := pem.Decode([]byte(publicKey.PEM))
+			if block == nil || block.Type != "PUBLIC KEY" || string(block.Bytes) != string(cert.RawSubjectPublicKeyInfo) || string(publicKey.DER.Bytes) != string(cert.RawSubjectPublicKeyInfo) {
+				t.Errorf("PEM = %q, DER = %x, want the SubjectPublicKeyInfo", publicKey.PEM, publicKey.DER.Bytes)
+			}
+			fingerprint := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			if publicKey.SHA256Fingerprint != hex.EncodeToString(fingerprint[:]) {
+				t.Errorf("SHA256Fingerprint = %s, want %x", publicKey.SHA256Fingerprint, fingerprint)
+			}
+
+			// the JWK is the same key
+			if publicKey.JWK == nil || publicKey.JWK.Kty != test.kty {
+				t.Fatalf("JWK = %+v, want a %s key", publicKey.JWK, test.kty)
+			}
+			if jwkKey := publicKeyOfJWK(t, publicKey.JWK); !jwkKey.(interface{ Equal(crypto.PublicKey) bool }).Equal(test.publicKey) {
+				t.Errorf("JWK = %+v, want the key of the certificate", publicKey.JWK)
+			}
+		})
+	}
+
+	t.Run("shared key", func(t *testing.T) {
+		// certificates of the same key have the same fingerprint, whatever else differs
+		key := newTestKey(t)
+		fingerprints := map[string]bool{}
+		for _, cn := range []string{"peer0", "peer1"} {
+			decodedIdBytes := &ParsedIdBytes{}
+			err := decodedIdBytes.DecodeIdBytes(nil, ca.issue(t, cn, &key.PublicKey, "peer").Raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fingerprints[decodedIdBytes.PublicKey.SHA256Fingerprint] = true
+		}
+		if len(fingerprints) != 1 {
+			t.Errorf("SHA256Fingerprint = %v, want the same one", fingerprints)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		// rather than the Go structs of crypto/ecdsa, with their empty Curve and decimal coordinates
+		decodedIdBytes := &ParsedIdBytes{}
+		err := decodedIdBytes.DecodeIdBytes(nil, ca.cert.Raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(decodedIdBytes.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"Algorithm":"ECDSA","Curve":"P-256","BitSize":256,"PEM":"-----BEGIN PUBLIC KEY-----\n`, `"JWK":{"kty":"EC","crv":"P-256","x":"`} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("json.Marshal() = %s, want %s", data, want)
+			}
+		}
+	})
+}
+
+// publicKeyOfJWK returns the public key of a JWK the way a JOSE library reads it
+func publicKeyOfJWK(t *testing.T, jwk *ParsedJWK) crypto.PublicKey {
+	t.Helper()
+	decode := func(value string) []byte {
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	switch jwk.Kty {
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		return &ecdsa.PublicKey{Curve: curves[jwk.Crv], X: new(big.Int).SetBytes(decode(jwk.X)), Y: new(big.Int).SetBytes(decode(jwk.Y))}
+	case "RSA":
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(decode(jwk.N)), E: int(new(big.Int).SetBytes(decode(jwk.E)).Int64())}
+	case "OKP":
+		return ed25519.PublicKey(decode(jwk.X))
+	}
+	t.Fatalf("JWK of the unknown kty %s", jwk.Kty)
+	return nil
+}
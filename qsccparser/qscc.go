@@ -0,0 +1,135 @@
+// Package qsccparser decodes the responses of the Hyperledger Fabric query system chaincode (qscc)
+// into Parsed* structures that marshal to readable JSON.
+package qsccparser
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+// Decoder decodes qscc responses. Its zero value stops at the first part that cannot be decoded,
+// like the package level functions do.
+type Decoder struct {
+	// Lenient keeps decoding past parts that cannot be decoded. Each of them is recorded with its raw
+	// bytes in the Failures of the node it belongs to, and listed in the Warnings of the result.
+	Lenient bool
+
+	// Logger receives what the Decode* methods report: every part that cannot be decoded at Warn,
+	// how it propagates and every decoded node at Debug. Decoding is silent when Logger is nil.
+	Logger *slog.Logger
+
+	// BytesEncoding of the bytes fields in the JSON output, base64 by default
+	BytesEncoding BytesEncoding
+	// RFC3339Timestamps writes the google.protobuf.Timestamp fields as RFC 3339 strings
+	RFC3339Timestamps bool
+	// SkipCertificates keeps certificates as they are instead of expanding them into ParsedIdBytes
+	SkipCertificates bool
+	// CertificateDetail adds the PEM, the fingerprints, the signature algorithm OID, the subject and
+	// issuer by attribute and the subject alternative names to each ParsedIdBytes
+	CertificateDetail bool
+	// MaxDepth drops the nodes more than MaxDepth nodes below the result, 0 keeps all of them. The nested
+	// messages of the deeper nodes are not decoded at all, so they are not checked either.
+	MaxDepth int
+	// MaxValueSize cuts the bytes fields to their first MaxValueSize bytes, 0 keeps them whole
+	MaxValueSize int
+
+	// VerifySignatures checks the signatures of the transaction envelopes and endorsements against the
+	// certificates of their creators and endorsers, see SignatureValid
+	VerifySignatures bool
+
+	// MSPs validates each ParsedSerializedIdentity against the CA certificates of its Mspid, see ReadMSPs
+	// and ParsedBlockData.MSPs
+	MSPs map[string]*MSP
+
+	// EndorsementPolicy is evaluated against the endorsements of each chaincode action, see ParsePolicy and
+	// ParsedChaincodeEndorsedAction.EndorsementPolicy
+	EndorsementPolicy *common.SignaturePolicyEnvelope
+
+	// DedupIdentities decodes each certificate once into the Identities of the result, keyed by the
+	// SHA-256 fingerprint of the certificate, and leaves only that fingerprint as the Ref of each ParsedIdBytes
+	DedupIdentities bool
+
+	transactionTime *time.Time                // of the transaction being decoded, for MSPs
+	identities      map[string]*ParsedIdBytes // of the result being decoded, for DedupIdentities
+	depth           int                       // of the node being decoded below the result, for MaxDepth
+}
+
+func (d *Decoder) logger() *slog.Logger {
+	if d == nil || d.Logger == nil {
+		return discardLogger
+	}
+	return d.Logger
+}
+
+// discardLogger is never enabled, so the decoded nodes are not even formatted
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// DecodeTransaction decodes the response of qscc GetTransactionByID, a marshaled peer.ProcessedTransaction.
+// A lenient decoder returns the result together with the DecodeErrors listed in its Warnings.
+func (d *Decoder) DecodeTransaction(data []byte) (*ParsedProcessedTransaction, error) {
+	decodedProcessedTransaction := &ParsedProcessedTransaction{}
+	err := decodedProcessedTransaction.DecodeProcessedTransaction(d, data)
+	if err != nil && !d.lenient() {
+		return nil, err
+	}
+	return decodedProcessedTransaction, err
+}
+
+// DecodeBlock decodes the response of qscc GetBlockByNumber, GetBlockByHash and GetBlockByTxID, a marshaled common.Block.
+// A lenient decoder returns the result together with the DecodeErrors listed in its Warnings.
+func (d *Decoder) DecodeBlock(data []byte) (*ParsedBlock, error) {
+	return d.DecodeBlockWithConsensusType(data, "")
+}
+
+// DecodeBlockWithConsensusType is DecodeBlock for a block cut by an orderer of the given
+// orderer.ConsensusType.Type, e.g. "etcdraft" or "BFT", so that its consenter metadata can be decoded.
+func (d *Decoder) DecodeBlockWithConsensusType(data []byte, consensusType string) (*ParsedBlock, error) {
+	decodedBlock := &ParsedBlock{}
+	err := decodedBlock.DecodeBlockWithConsensusType(d, data, consensusType)
+	if err != nil && !d.lenient() {
+		return nil, err
+	}
+	return decodedBlock, err
+}
+
+// DecodeChainInfo decodes the response of qscc GetChainInfo, a marshaled common.BlockchainInfo.
+// A lenient decoder returns the result together with the DecodeErrors listed in its Warnings.
+func (d *Decoder) DecodeChainInfo(data []byte) (*ParsedBlockchainInfo, error) {
+	decodedBlockchainInfo := &ParsedBlockchainInfo{}
+	err := decodedBlockchainInfo.DecodeBlockchainInfo(d, data)
+	if err != nil && !d.lenient() {
+		return nil, err
+	}
+	return decodedBlockchainInfo, err
+}
+
+// DecodeTransaction decodes the response of qscc GetTransactionByID, a marshaled peer.ProcessedTransaction.
+func DecodeTransaction(data []byte) (*ParsedProcessedTransaction, error) {
+	return (&Decoder{}).DecodeTransaction(data)
+}
+
+// DecodeBlock decodes the response of qscc GetBlockByNumber, GetBlockByHash and GetBlockByTxID, a marshaled common.Block.
+func DecodeBlock(data []byte) (*ParsedBlock, error) {
+	return (&Decoder{}).DecodeBlock(data)
+}
+
+// DecodeBlockWithConsensusType is DecodeBlock for a block cut by an orderer of the given
+// orderer.ConsensusType.Type, e.g. "etcdraft" or "BFT", so that its consenter metadata can be decoded.
+func DecodeBlockWithConsensusType(data []byte, consensusType string) (*ParsedBlock, error) {
+	return (&Decoder{}).DecodeBlockWithConsensusType(data, consensusType)
+}
+
+// DecodeChainInfo decodes the response of qscc GetChainInfo, a marshaled common.BlockchainInfo.
+func DecodeChainInfo(data []byte) (*ParsedBlockchainInfo, error) {
+	return (&Decoder{}).DecodeChainInfo(data)
+}
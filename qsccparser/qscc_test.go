@@ -0,0 +1,104 @@
+package qsccparser
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingHandler keeps the messages of the records it handles, with their level
+type recordingHandler struct {
+	level   slog.Level
+	mu      sync.Mutex
+	records []string // e.g. "WARN cannot decode"
+}
+
+func (h *recordingHandler) Enabled(_ context.Context, level slog.Level) bool { return level >= h.level }
+
+func (h *recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, record.Level.String()+" "+record.Message)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+// count returns how many records have the message, at the level
+func (h *recordingHandler) count(level slog.Level, message string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	count := 0
+	for _, record := range h.records {
+		if record == level.String()+" "+message {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDecoderLogger(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	transaction := (&testTransaction{creator: creator}).marshal(t)
+	malformed := (&testTransaction{creator: creator, chaincodeActionPayload: []byte{0x0a, 0xff}}).marshal(t)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		level       slog.Level
+		lenient     bool
+		warnings    int // the Warn records of the parts that cannot be decoded
+		decodedRoot int // the Debug records of the decoded root
+	}{
+		{"decoded at Debug", transaction, slog.LevelDebug, false, 0, 1},
+		{"decoded at Warn", transaction, slog.LevelWarn, false, 0, 0},
+		{"malformed", malformed, slog.LevelWarn, false, 1, 0},
+		{"malformed lenient", malformed, slog.LevelDebug, true, 1, 1},
+		{"malformed at Error", malformed, slog.LevelError, true, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &recordingHandler{level: test.level}
+			decoder := &Decoder{Logger: slog.New(handler), Lenient: test.lenient}
+			_, _ = decoder.DecodeTransaction(test.data)
+			if count := handler.count(slog.LevelWarn, "cannot decode"); count != test.warnings {
+				t.Errorf("%d Warn records, want %d: %v", count, test.warnings, handler.records)
+			}
+			if count := handler.count(slog.LevelDebug, "DecodedProcessedTransaction"); count != test.decodedRoot {
+				t.Errorf("%d DecodedProcessedTransaction records, want %d", count, test.decodedRoot)
+			}
+		})
+	}
+}
+
+func TestDecoderWithoutLoggerIsSilent(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	malformed := (&testTransaction{creator: ca.signer(t, "Org1MSP", "user1"), chaincodeActionPayload: []byte{0x0a, 0xff}}).marshal(t)
+
+	// the decoders used to print to stdout, which is where the CLI writes its output
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = writer
+	for _, decoder := range []*Decoder{nil, {}, {Lenient: true}} {
+		_, _ = decoder.DecodeTransaction(malformed)
+	}
+	os.Stdout = stdout
+	writer.Close()
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(strings.TrimSpace(string(output))) > 0 {
+		t.Errorf("decoding wrote to stdout: %s", output)
+	}
+}
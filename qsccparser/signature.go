@@ -0,0 +1,110 @@
+package qsccparser
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func (d *Decoder) verifySignatures() bool {
+	return d != nil && d.VerifySignatures
+}
+
+// verifyEnvelopeSignature checks the signature of an envelope over its payload bytes against the creator in the
+// signature header of the payload
+func verifyEnvelopeSignature(envelope *common.Envelope, payload *common.Payload) (*bool, string) {
+	signatureHeader := &common.SignatureHeader{}
+	err := signatureHeader.XXX_Unmarshal(payload.GetHeader().GetSignatureHeader())
+	if err != nil {
+		return signatureResult(fmt.Errorf("cannot decode the signature header: %w", err))
+	}
+	if len(signatureHeader.GetCreator()) == 0 {
+		return signatureResult(errors.New("the signature header has no creator"))
+	}
+	return signatureResult(verifySignature(signatureHeader.GetCreator(), envelope.GetPayload(), envelope.GetSignature()))
+}
+
+// verifyEndorsementSignature checks the signature of an endorsement over the proposal response payload bytes
+// followed by the endorser bytes against the endorser
+func verifyEndorsementSignature(endorsement *peer.Endorsement, proposalResponsePayload []byte) (*bool, string) {
+	message := make([]byte, 0, len(proposalResponsePayload)+len(endorsement.GetEndorser()))
+	message = append(message, proposalResponsePayload...)
+	message = append(message, endorsement.GetEndorser()...)
+	return signatureResult(verifySignature(endorsement.GetEndorser(), message, endorsement.GetSignature()))
+}
+
+// computeTxId computes the TxId of a transaction the way Fabric does, the hex of the SHA-256 of the nonce
+// followed by the creator of its signature header
+func computeTxId(nonce []byte, creator []byte) string {
+	digest := sha256.New()
+	digest.Write(nonce)
+	digest.Write(creator)
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+func signatureResult(err error) (*bool, string) {
+	valid := err == nil
+	if err != nil {
+		return &valid, err.Error()
+	}
+	return &valid, ""
+}
+
+// verifySignature checks signature over message against the certificate of identity, a marshaled
+// msp.SerializedIdentity, the way the bccsp of a Fabric peer does: ECDSA over the SHA-256 of the message
+// with a low S, or Ed25519 over the message itself
+func verifySignature(identity []byte, message []byte, signature []byte) error {
+	serializedIdentity := &msp.SerializedIdentity{}
+	err := serializedIdentity.XXX_Unmarshal(identity)
+	if err != nil {
+		return fmt.Errorf("cannot decode the identity: %w", err)
+	}
+
+	switch identityKind(serializedIdentity.GetIdBytes()) {
+	case IdentityIdemix:
+		return fmt.Errorf("cannot verify signatures of the Idemix identity of %s", serializedIdentity.GetMspid())
+	case IdentityUnknown:
+		return errUnknownIdentity(serializedIdentity.GetMspid())
+	}
+	cert, err := parseCertificate(serializedIdentity.GetIdBytes())
+	if err != nil {
+		return fmt.Errorf("cannot parse the certificate of the identity: %w", err)
+	}
+
+	switch publicKey := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		ecdsaSignature := struct{ R, S *big.Int }{}
+		_, err := asn1.Unmarshal(signature, &ecdsaSignature)
+		if err != nil {
+			return fmt.Errorf("cannot decode the ECDSA signature: %w", err)
+		}
+		if ecdsaSignature.S.Cmp(halfOrder(publicKey.Curve)) > 0 {
+			return errors.New("the ECDSA signature has a high S")
+		}
+		digest := sha256.Sum256(message)
+		if !ecdsa.Verify(publicKey, digest[:], ecdsaSignature.R, ecdsaSignature.S) {
+			return fmt.Errorf("the ECDSA signature does not match the public key of %s", cert.Subject)
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, message, signature) {
+			return fmt.Errorf("the Ed25519 signature does not match the public key of %s", cert.Subject)
+		}
+	default:
+		return fmt.Errorf("cannot verify signatures of %s keys", cert.PublicKeyAlgorithm)
+	}
+	return nil
+}
+
+func halfOrder(curve elliptic.Curve) *big.Int {
+	return new(big.Int).Rsh(curve.Params().N, 1)
+}
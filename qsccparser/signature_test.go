@@ -0,0 +1,217 @@
+package qsccparser
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// highS turns an ECDSA signature of signer into the other valid signature of the same message, with a high S
+func highS(t *testing.T, signer *testSigner, signature []byte) []byte {
+	t.Helper()
+	ecdsaSignature := struct{ R, S *big.Int }{}
+	_, err := asn1.Unmarshal(signature, &ecdsaSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSignature.S.Sub(signer.key.Curve.Params().N, ecdsaSignature.S)
+	signature, err = asn1.Marshal(ecdsaSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+func TestVerifySignature(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	signer := ca.signer(t, "Org1MSP", "user1", "client")
+	other := ca.signer(t, "Org1MSP", "user2", "client")
+	message := []byte("payload")
+	signature := signer.sign(t, message)
+
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edIdentity := testIdentity(t, "Org1MSP", ca.issue(t, "user3", edPublicKey).Raw)
+
+	ou := testMarshal(t, &msp.OrganizationUnit{MspIdentifier: "IdemixMSP", OrganizationalUnitIdentifier: "department1"})
+	role := testMarshal(t, &msp.MSPRole{MspIdentifier: "IdemixMSP", Role: msp.MSPRole_MEMBER})
+	idemixIdentity := testIdentity(t, "IdemixMSP", testMarshal(t, &msp.SerializedIdemixIdentity{NymX: []byte{1}, NymY: []byte{2}, Ou: ou, Role: role}))
+
+	tests := []struct {
+		name      string
+		identity  []byte
+		message   []byte
+		signature []byte
+		err       string // a part of the error, none when empty
+	}{
+		{"low S", signer.identity, message, signature, ""},
+		{"high S", signer.identity, message, highS(t, signer, signature), "high S"},
+		{"tampered message", signer.identity, []byte("payload2"), signature, "does not match"},
+		{"tampered signature", signer.identity, message, signer.sign(t, []byte("payload2")), "does not match"},
+		{"other signer", other.identity, message, signature, "does not match"},
+		{"not DER", signer.identity, message, []byte("signature"), "cannot decode the ECDSA signature"},
+		{"Ed25519", edIdentity, message, ed25519.Sign(edPrivateKey, message), ""},
+		{"tampered Ed25519", edIdentity, []byte("payload2"), ed25519.Sign(edPrivateKey, message), "does not match"},
+		{"Idemix", idemixIdentity, message, signature, "Idemix"},
+		{"unknown identity", testIdentity(t, "Org1MSP", []byte("junk")), message, signature, "neither an X.509 certificate nor an Idemix identity"},
+		{"not an identity", []byte{0x0a, 0xff}, message, signature, "cannot decode the identity"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifySignature(test.identity, test.message, test.signature)
+			switch {
+			case test.err == "" && err != nil:
+				t.Errorf("verifySignature() = %v, want nil", err)
+			case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+				t.Errorf("verifySignature() = %v, want an error with %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestVerifyEndorsementSignature(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	endorser := ca.signer(t, "Org1MSP", "peer0", "peer")
+	other := ca.signer(t, "Org1MSP", "peer1", "peer")
+	proposalResponsePayload := []byte("proposal response payload")
+	message := append(append([]byte{}, proposalResponsePayload...), endorser.identity...)
+
+	tests := []struct {
+		name        string
+		endorsement *peer.Endorsement
+		valid       bool
+	}{
+		{"over the payload and the endorser", &peer.Endorsement{Endorser: endorser.identity, Signature: endorser.sign(t, message)}, true},
+		{"over the payload alone", &peer.Endorsement{Endorser: endorser.identity, Signature: endorser.sign(t, proposalResponsePayload)}, false},
+		{"high S", &peer.Endorsement{Endorser: endorser.identity, Signature: highS(t, endorser, endorser.sign(t, message))}, false},
+		{"of another endorser", &peer.Endorsement{Endorser: other.identity, Signature: endorser.sign(t, message)}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			valid, reason := verifyEndorsementSignature(test.endorsement, proposalResponsePayload)
+			if valid == nil || *valid != test.valid {
+				t.Fatalf("verifyEndorsementSignature() = %v, want %v", valid, test.valid)
+			}
+			if test.valid != (reason == "") {
+				t.Errorf("verifyEndorsementSignature() reason = %q", reason)
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionVerifySignatures(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	endorser := ca.signer(t, "Org1MSP", "peer0", "peer")
+
+	tests := []struct {
+		name             string
+		transaction      *testTransaction
+		endorsementValid bool
+	}{
+		{"signed", &testTransaction{creator: creator, endorsers: []*testSigner{endorser}}, true},
+		{"tampered endorsement", &testTransaction{creator: creator, endorsers: []*testSigner{endorser}, tamper: func(endorsements []*peer.Endorsement) {
+			endorsements[0].Signature = endorser.sign(t, []byte("something else"))
+		}}, false},
+		{"high S endorsement", &testTransaction{creator: creator, endorsers: []*testSigner{endorser}, tamper: func(endorsements []*peer.Endorsement) {
+			endorsements[0].Signature = highS(t, endorser, endorsements[0].Signature)
+		}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decoder := &Decoder{VerifySignatures: true}
+			processedTransaction, err := decoder.DecodeTransaction(test.transaction.marshal(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			envelope := processedTransaction.TransactionEnvelope
+			if envelope.SignatureValid == nil || !*envelope.SignatureValid {
+				t.Errorf("SignatureValid = %v, want true: %s", envelope.SignatureValid, envelope.SignatureReason)
+			}
+			endorsement := endorsedAction(t, processedTransaction).Endorsements[0]
+			if endorsement.SignatureValid == nil || *endorsement.SignatureValid != test.endorsementValid {
+				t.Errorf("Endorsements[0].SignatureValid = %v, want %v", endorsement.SignatureValid, test.endorsementValid)
+			}
+			if test.endorsementValid != (endorsement.SignatureReason == "") {
+				t.Errorf("Endorsements[0].SignatureReason = %q", endorsement.SignatureReason)
+			}
+		})
+	}
+
+	t.Run("not verified", func(t *testing.T) {
+		processedTransaction, err := DecodeTransaction((&testTransaction{creator: creator, endorsers: []*testSigner{endorser}}).marshal(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if processedTransaction.TransactionEnvelope.SignatureValid != nil || endorsedAction(t, processedTransaction).Endorsements[0].SignatureValid != nil {
+			t.Error("a Decoder that does not verify signatures sets SignatureValid")
+		}
+	})
+}
+
+func TestComputeTxId(t *testing.T) {
+	tests := []struct {
+		nonce   string
+		creator string
+		txId    string
+	}{
+		// the SHA-256 of "abc", whichever way it is split
+		{"abc", "", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"a", "bc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"", "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"", "", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	}
+	for _, test := range tests {
+		t.Run(test.nonce+"|"+test.creator, func(t *testing.T) {
+			if txId := computeTxId([]byte(test.nonce), []byte(test.creator)); txId != test.txId {
+				t.Errorf("computeTxId() = %s, want %s", txId, test.txId)
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionTxId(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	creator := ca.signer(t, "Org1MSP", "user1", "client")
+	other := ca.signer(t, "Org1MSP", "user2", "client")
+	txId := computeTxId(testNonce, creator.identity)
+
+	tests := []struct {
+		name  string
+		txId  string
+		valid bool
+	}{
+		{"computed", txId, true},
+		{"upper case", strings.ToUpper(txId), false},
+		{"of another creator", computeTxId(testNonce, other.identity), false},
+		{"of another nonce", computeTxId([]byte("nonce"), creator.identity), false},
+		{"not hex", "tx1", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			processedTransaction, err := DecodeTransaction((&testTransaction{creator: creator, txId: test.txId}).marshal(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			channelHeader := processedTransaction.TransactionEnvelope.Payload.Header.ChannelHeader
+			if channelHeader.TxIdValid == nil || *channelHeader.TxIdValid != test.valid {
+				t.Fatalf("TxIdValid = %v, want %v", channelHeader.TxIdValid, test.valid)
+			}
+			computedTxId := txId
+			if test.valid {
+				computedTxId = ""
+			}
+			if channelHeader.ComputedTxId != computedTxId {
+				t.Errorf("ComputedTxId = %q, want %q", channelHeader.ComputedTxId, computedTxId)
+			}
+		})
+	}
+}
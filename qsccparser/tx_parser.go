@@ -0,0 +1,1821 @@
+package qsccparser
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+type ParsedProcessedTransaction struct {
+	// *peer.ProcessedTransaction
+	ValidationCode      ParsedEnum                 //func (*peer.ProcessedTransaction).GetValidationCode() int32
+	TransactionEnvelope *ParsedTransactionEnvelope //func (*peer.ProcessedTransaction).GetTransactionEnvelope() *common.Envelope
+	Failures            []*ParsedFailure           `json:",omitempty"` //parts a lenient Decoder could not decode
+	Warnings            []string                   `json:",omitempty"` //every failure below, with its full path
+	Identities          map[string]*ParsedIdBytes  `json:",omitempty"` //the certificates below by fingerprint, from a Decoder that dedups identities
+}
+
+func (dpt *ParsedProcessedTransaction) DecodeProcessedTransaction(decoder *Decoder, data []byte) (err error) {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+	decoder = decoder.withIdentities()
+	defer func() { locateError(data, err) }()
+
+	processedTransaction := &peer.ProcessedTransaction{}
+	err = processedTransaction.XXX_Unmarshal(data)
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dpt.Failures, unmarshalError("", processedTransaction, data, err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedTransactionEnvelope := &ParsedTransactionEnvelope{}
+	err = decodedTransactionEnvelope.DecodeTransactionEnvelope(decoder, processedTransaction.GetTransactionEnvelope())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("TransactionEnvelope", err)
+	}
+
+	dpt.TransactionEnvelope = decodedTransactionEnvelope
+	dpt.ValidationCode = validationCodeEnum(processedTransaction.GetValidationCode())
+	dpt.Identities = decoder.identitiesOf()
+
+	logger.Debug("DecodedProcessedTransaction", "value", dpt)
+
+	err = collectWarnings(dpt, data, &dpt.Warnings)
+	decoder.cutDepth(dpt)
+
+	return err
+}
+
+type ParsedTransactionEnvelope struct {
+	// *common.Envelope
+	Payload   *ParsedPayload   //func (*common.Envelope).GetPayload() *common.Payload
+	Signature ParsedBytes      //func (*common.Envelope).GetSignature() []byte
+	Failures  []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+
+	SignatureValid  *bool  `json:",omitempty"` //whether Signature is the creator's over Payload, from a Decoder that verifies signatures
+	SignatureReason string `json:",omitempty"` //why Signature is not valid
+}
+
+func (dte *ParsedTransactionEnvelope) DecodeTransactionEnvelope(decoder *Decoder, envelope *common.Envelope) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	envPayload := &common.Payload{}
+	err := envPayload.XXX_Unmarshal(envelope.GetPayload())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dte.Failures, unmarshalError("Payload", envPayload, envelope.GetPayload(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedPayload := &ParsedPayload{}
+	err = decodedPayload.DecodePayload(decoder, envPayload)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Payload", err)
+	}
+	dte.Payload = decodedPayload
+
+	dte.Signature = decoder.bytes(envelope.GetSignature())
+
+	if decoder.verifySignatures() {
+		dte.SignatureValid, dte.SignatureReason = verifyEnvelopeSignature(envelope, envPayload)
+	}
+
+	logger.Debug("DecodedTransactionEnvelope", "value", dte)
+
+	return nil
+}
+
+type ParsedPayload struct {
+	// *common.Payload
+	Header   *ParsedHeader    //func (*common.Payload).GetHeader() *common.Header
+	Data     interface{}      //func (*common.Payload).GetData() []byte, decoded according to Header.ChannelHeader.Type
+	Failures []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dp *ParsedPayload) DecodePayload(decoder *Decoder, payload *common.Payload) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	// the identities in the payload are validated at the time of its transaction
+	decoder = decoder.withChannelHeader(payload.GetHeader().GetChannelHeader())
+
+	decodedHeader := &ParsedHeader{}
+	err := decodedHeader.DecodeHeader(decoder, payload.GetHeader())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Header", err)
+	}
+	dp.Header = decodedHeader
+
+	// the type is read from the channel header itself, the decoded one is empty past MaxDepth
+	channelHeader := &common.ChannelHeader{}
+	_ = channelHeader.XXX_Unmarshal(payload.GetHeader().GetChannelHeader())
+	switch common.HeaderType(channelHeader.GetType()) {
+	case common.HeaderType_CONFIG:
+		configEnvelope := &common.ConfigEnvelope{}
+		err := configEnvelope.XXX_Unmarshal(payload.GetData())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dp.Failures, unmarshalError("Data", configEnvelope, payload.GetData(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedConfigEnvelope := &ParsedConfigEnvelope{}
+		err = decodedConfigEnvelope.DecodeConfigEnvelope(decoder, configEnvelope)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Data", err)
+		}
+		dp.Data = decodedConfigEnvelope
+	case common.HeaderType_CONFIG_UPDATE:
+		configUpdateEnvelope := &common.ConfigUpdateEnvelope{}
+		err := configUpdateEnvelope.XXX_Unmarshal(payload.GetData())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dp.Failures, unmarshalError("Data", configUpdateEnvelope, payload.GetData(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedConfigUpdateEnvelope := &ParsedConfigUpdateEnvelope{}
+		err = decodedConfigUpdateEnvelope.DecodeConfigUpdateEnvelope(decoder, configUpdateEnvelope)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Data", err)
+		}
+		dp.Data = decodedConfigUpdateEnvelope
+	case common.HeaderType_ENDORSER_TRANSACTION:
+		payloadData := &peer.Transaction{}
+		err := payloadData.XXX_Unmarshal(payload.GetData())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dp.Failures, unmarshalError("Data", payloadData, payload.GetData(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedData := &ParsedData{}
+		err = decodedData.DecodeData(decoder, payloadData)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Data", err)
+		}
+		dp.Data = decodedData
+	case common.HeaderType_ORDERER_TRANSACTION:
+		// the system channel wraps the config transaction of a new channel in another envelope
+		envelope := &common.Envelope{}
+		err := envelope.XXX_Unmarshal(payload.GetData())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dp.Failures, unmarshalError("Data", envelope, payload.GetData(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedTransactionEnvelope := &ParsedTransactionEnvelope{}
+		err = decodedTransactionEnvelope.DecodeTransactionEnvelope(decoder, envelope)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Data", err)
+		}
+		dp.Data = decodedTransactionEnvelope
+	case HeaderType_PEER_ADMIN_OPERATION:
+		decodedAdminOperation := &ParsedAdminOperation{}
+		err = decodedAdminOperation.DecodeAdminOperation(decoder, payload.GetData())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Data", err)
+		}
+		dp.Data = decodedAdminOperation
+	default:
+		decodedRawPayloadData := &ParsedRawPayloadData{}
+		err = decodedRawPayloadData.DecodeRawPayloadData(decoder, channelHeader.GetType(), payload.GetData())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Data", err)
+		}
+		dp.Data = decodedRawPayloadData
+	}
+
+	logger.Debug("DecodedPayload", "value", dp)
+
+	return nil
+}
+
+type ParsedHeader struct {
+	// *common.Header
+	ChannelHeader   *ParsedChannelHeader   //func (*common.Header).GetChannelHeader() []byte
+	SignatureHeader *ParsedSignatureHeader //func (*common.Header).GetSignatureHeader() []byte
+	Failures        []*ParsedFailure       `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dh *ParsedHeader) DecodeHeader(decoder *Decoder, header *common.Header) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	channelHeader := &common.ChannelHeader{}
+	err := channelHeader.XXX_Unmarshal(header.GetChannelHeader())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dh.Failures, unmarshalError("ChannelHeader", channelHeader, header.GetChannelHeader(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedChannelHeader := &ParsedChannelHeader{}
+	err = decodedChannelHeader.DecodeChannelHeader(decoder, channelHeader)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChannelHeader", err)
+	}
+	dh.ChannelHeader = decodedChannelHeader
+
+	signatureHeader := &common.SignatureHeader{}
+	err = signatureHeader.XXX_Unmarshal(header.GetSignatureHeader())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dh.Failures, unmarshalError("SignatureHeader", signatureHeader, header.GetSignatureHeader(), err))
+		if err != nil {
+			return err
+		}
+	}
+	decodedSignatureHeader := &ParsedSignatureHeader{}
+	err = decodedSignatureHeader.DecodeSignatureHeader(decoder, signatureHeader)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("SignatureHeader", err)
+	}
+	dh.SignatureHeader = decodedSignatureHeader
+
+	if channelHeader.GetTxId() != "" {
+		txId := computeTxId(signatureHeader.GetNonce(), signatureHeader.GetCreator())
+		txIdValid := txId == channelHeader.GetTxId()
+		decodedChannelHeader.TxIdValid = &txIdValid
+		if !txIdValid {
+			decodedChannelHeader.ComputedTxId = txId
+		}
+	}
+
+	logger.Debug("DecodedHeader", "value", dh)
+
+	return nil
+}
+
+type ParsedChannelHeader struct {
+	// *common.ChannelHeader
+	Type        ParsedEnum       //func (*common.ChannelHeader).GetType() int32
+	Version     int32            //func (*common.ChannelHeader).GetVersion() int32
+	Timestamp   ParsedTimestamp  //func (*common.ChannelHeader).GetTimestamp() *timestamppb.Timestamp
+	ChannelId   string           //func (*common.ChannelHeader).GetChannelId() string
+	TxId        string           //func (*common.ChannelHeader).GetTxId() string
+	Epoch       uint64           //func (*common.ChannelHeader).GetEpoch() uint64
+	Extension   interface{}      //func (*common.ChannelHeader).GetExtension() []byte, decoded according to Type
+	TlsCertHash ParsedBytes      //func (*common.ChannelHeader).GetTlsCertHash() []byte
+	Failures    []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+
+	TxIdValid    *bool  `json:",omitempty"` //whether TxId is the hex of the SHA-256 of the nonce and creator of the SignatureHeader, for a TxId that is set
+	ComputedTxId string `json:",omitempty"` //the hex of the SHA-256 of the nonce and creator, when it is not TxId
+}
+
+func (dch *ParsedChannelHeader) DecodeChannelHeader(decoder *Decoder, channelHeader *common.ChannelHeader) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dch.Type = headerTypeEnum(channelHeader.GetType())
+	dch.Version = channelHeader.GetVersion()
+	dch.Timestamp = decoder.timestamp(channelHeader.GetTimestamp())
+	dch.ChannelId = channelHeader.GetChannelId()
+	dch.TxId = channelHeader.GetTxId()
+	dch.Epoch = channelHeader.GetEpoch()
+
+	if common.HeaderType(channelHeader.GetType()) == common.HeaderType_ENDORSER_TRANSACTION {
+		chaincodeHeaderExtension := &peer.ChaincodeHeaderExtension{}
+		err := chaincodeHeaderExtension.XXX_Unmarshal(channelHeader.GetExtension())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dch.Failures, unmarshalError("Extension", chaincodeHeaderExtension, channelHeader.GetExtension(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedChaincodeHeaderExtension := &ParsedChaincodeHeaderExtension{}
+		err = decodedChaincodeHeaderExtension.DecodeChaincodeHeaderExtension(decoder, chaincodeHeaderExtension)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Extension", err)
+		}
+		dch.Extension = decodedChaincodeHeaderExtension
+	} else {
+		dch.Extension = decoder.bytes(channelHeader.GetExtension())
+	}
+
+	dch.TlsCertHash = decoder.bytes(channelHeader.GetTlsCertHash())
+
+	logger.Debug("DecodedChannelHeader", "value", dch)
+
+	return nil
+}
+
+type ParsedChaincodeHeaderExtension struct {
+	// *peer.ChaincodeHeaderExtension
+	ChaincodeId *ParsedChaincodeId //func (*peer.ChaincodeHeaderExtension).GetChaincodeId() *peer.ChaincodeID
+}
+
+func (dche *ParsedChaincodeHeaderExtension) DecodeChaincodeHeaderExtension(decoder *Decoder, chaincodeHeaderExtension *peer.ChaincodeHeaderExtension) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedChaincodeId := &ParsedChaincodeId{}
+	err := decodedChaincodeId.DecodeChaincodeId(decoder, chaincodeHeaderExtension.GetChaincodeId())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChaincodeId", err)
+	}
+	dche.ChaincodeId = decodedChaincodeId
+
+	logger.Debug("DecodedChaincodeHeaderExtension", "value", dche)
+
+	return nil
+}
+
+type ParsedSignatureHeader struct {
+	// *common.SignatureHeader
+	Creator  *ParsedSerializedIdentity //func (*common.SignatureHeader).GetCreator() []byte
+	Nonce    ParsedBytes               //func (*common.SignatureHeader).GetNonce() []byte
+	Failures []*ParsedFailure          `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dsh *ParsedSignatureHeader) DecodeSignatureHeader(decoder *Decoder, signatureHeader *common.SignatureHeader) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	// config transactions generated by the orderer, e.g. in the genesis block, carry no creator
+	if len(signatureHeader.GetCreator()) > 0 {
+		serializedIdentity := &msp.SerializedIdentity{}
+		err := serializedIdentity.XXX_Unmarshal(signatureHeader.GetCreator())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dsh.Failures, unmarshalError("Creator", serializedIdentity, signatureHeader.GetCreator(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedSerializedIdentity := &ParsedSerializedIdentity{}
+		err = decodedSerializedIdentity.DecodeSerializedIdentity(decoder, serializedIdentity)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Creator", err)
+		}
+		dsh.Creator = decodedSerializedIdentity
+	}
+
+	dsh.Nonce = decoder.bytes(signatureHeader.GetNonce())
+
+	logger.Debug("DecodedSignatureHeader", "value", dsh)
+
+	return nil
+}
+
+type ParsedSerializedIdentity struct {
+	// *msp.SerializedIdentity
+	Mspid   string                //func (*msp.SerializedIdentity).GetMspid() string
+	Kind    string                //of the IdBytes: IdentityX509, IdentityIdemix or IdentityUnknown
+	IdBytes *ParsedIdBytes        `json:",omitempty"` //func (*msp.SerializedIdentity).GetIdBytes() []byte, of an IdentityX509
+	Idemix  *ParsedIdemixIdentity `json:",omitempty"` //func (*msp.SerializedIdentity).GetIdBytes() []byte, of an IdentityIdemix
+
+	Role       string                    `json:",omitempty"` //RoleClient, RolePeer, RoleAdmin, RoleOrderer or RoleMember in the MSP of Mspid, from a Decoder with MSPs
+	Org        string                    `json:",omitempty"` //the organization of the MSP of Mspid, from a Decoder with MSPs
+	Validation *ParsedIdentityValidation `json:",omitempty"` //against the CA certificates of Mspid, from a Decoder with MSPs
+	Failures   []*ParsedFailure          `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dsi *ParsedSerializedIdentity) DecodeSerializedIdentity(decoder *Decoder, serializedIdentity *msp.SerializedIdentity) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dsi.Mspid = serializedIdentity.GetMspid()
+	dsi.Kind = identityKind(serializedIdentity.GetIdBytes())
+
+	switch dsi.Kind {
+	case IdentityX509:
+		decodedIdBytes := &ParsedIdBytes{}
+		err := decodedIdBytes.DecodeIdBytes(decoder, serializedIdentity.GetIdBytes())
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("IdBytes", err)
+		}
+		dsi.IdBytes = decodedIdBytes
+	case IdentityIdemix:
+		idemixIdentity := &msp.SerializedIdemixIdentity{}
+		err := idemixIdentity.XXX_Unmarshal(serializedIdentity.GetIdBytes())
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dsi.Failures, unmarshalError("Idemix", idemixIdentity, serializedIdentity.GetIdBytes(), err))
+			if err != nil {
+				return err
+			}
+		}
+
+		decodedIdemixIdentity := &ParsedIdemixIdentity{}
+		err = decodedIdemixIdentity.DecodeIdemixIdentity(decoder, idemixIdentity)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath("Idemix", err)
+		}
+		dsi.Idemix = decodedIdemixIdentity
+	default:
+		err := errUnknownIdentity(serializedIdentity.GetMspid())
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dsi.Failures, decodeError("IdBytes", "msp.SerializedIdentity.IdBytes", serializedIdentity.GetIdBytes(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	if decoder.validatesIdentities() {
+		dsi.Role, dsi.Org = decoder.classifyIdentity(serializedIdentity)
+		dsi.Validation = decoder.validateIdentity(serializedIdentity)
+	}
+
+	logger.Debug("DecodedSerializedIdentity", "value", dsi)
+
+	return nil
+}
+
+type ParsedIdBytes struct {
+	// basic info of *x509.Certificate
+	Signature             ParsedBytes
+	SignatureAlgorithm    string            // (*x509.Certificate).SignatureAlgorithm() x509.SignatureAlgorithm
+	PublicKeyAlgorithm    string            // (*x509.Certificate).PublicKeyAlgorithm() x509.PublicKeyAlgorithm
+	PublicKey             *ParsedPublicKey  // (*x509.Certificate).PublicKey() interface{}
+	Version               int               // (*x509.Certificate).Version() int
+	SerialNumber          *big.Int          // (*x509.Certificate).SerialNumber() *math.big.Int
+	Issuer                string            // (*x509.Certificate).Issuer() x509.Certificate.pkix.Name
+	Subject               string            // (*x509.Certificate).Subject() x509.Certificate.pkix.Name
+	NotBefore             time.Time         // (*x509.Certificate).NotBefore() time.Time
+	NotAfter              time.Time         // (*x509.Certificate).NotAfter() time.Time
+	KeyUsage              x509.KeyUsage     // (*x509.Certificate).KeyUsage() x509.KeyUsage
+	Extensions            []ParsedExtension // (*x509.Certificate).Extensions() []pkix.Extension
+	BasicConstraintsValid bool              // (*x509.Certificate).BasicConstraintsValid() bool
+	IsCA                  bool              // (*x509.Certificate).IsCA() bool
+	MaxPathLen            int               // (*x509.Certificate).MaxPathLen() int
+	MaxPathLenZero        bool              // (*x509.Certificate).MaxPathLenZero() bool
+	SubjectKeyId          ParsedBytes       // (*x509.Certificate).SubjectKeyId() []byte
+	AuthorityKeyId        ParsedBytes       // (*x509.Certificate).AuthorityKeyId() []byte
+	// well-known extensions
+	Attributes            map[string]string `json:",omitempty"` // attrs of the Fabric CA extension 1.2.3.4.5.6.7.8.1, e.g. hf.EnrollmentID
+	DNSNames              []string          `json:",omitempty"` // (*x509.Certificate).DNSNames() []string
+	EmailAddresses        []string          `json:",omitempty"` // (*x509.Certificate).EmailAddresses() []string
+	IPAddresses           []string          `json:",omitempty"` // (*x509.Certificate).IPAddresses() []net.IP
+	URIs                  []string          `json:",omitempty"` // (*x509.Certificate).URIs() []*url.URL
+	ExtKeyUsage           []string          `json:",omitempty"` // (*x509.Certificate).ExtKeyUsage() []x509.ExtKeyUsage, then UnknownExtKeyUsage() []asn1.ObjectIdentifier
+	SubjectKeyIdHex       string            `json:",omitempty"` // SubjectKeyId as hex, like openssl prints it
+	AuthorityKeyIdHex     string            `json:",omitempty"` // AuthorityKeyId as hex, like openssl prints it
+	CRLDistributionPoints []string          `json:",omitempty"` // (*x509.Certificate).CRLDistributionPoints() []string
+	// full detail, from a Decoder with CertificateDetail
+	PEM                   string      `json:",omitempty"` // the certificate as a CERTIFICATE PEM block
+	SHA1Fingerprint       string      `json:",omitempty"` // hex of the SHA-1 of the DER of the certificate
+	SHA256Fingerprint     string      `json:",omitempty"` // hex of the SHA-256 of the DER of the certificate
+	SignatureAlgorithmOID string      `json:",omitempty"` // the OID SignatureAlgorithm names
+	SubjectName           *ParsedName `json:",omitempty"` // (*x509.Certificate).Subject() pkix.Name
+	IssuerName            *ParsedName `json:",omitempty"` // (*x509.Certificate).Issuer() pkix.Name
+	SubjectAltNames       []string    `json:",omitempty"` // DNSNames, EmailAddresses, IPAddresses and URIs, like openssl prints them
+	//...
+	Raw      *ParsedBytes     `json:",omitempty"` //the certificate as it is, instead of the fields above, from a Decoder that skips certificates or when it cannot be parsed
+	Ref      string           `json:",omitempty"` //the fingerprint of the certificate in the Identities of the result, instead of the fields above, from a Decoder that dedups identities
+	Failures []*ParsedFailure `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+// MarshalJSON leaves out the zero fields of a certificate that was not expanded, so that they are not taken for its content
+func (dib *ParsedIdBytes) MarshalJSON() ([]byte, error) {
+	if dib.Raw != nil {
+		return json.Marshal(struct {
+			Raw      *ParsedBytes
+			Failures []*ParsedFailure `json:",omitempty"`
+		}{dib.Raw, dib.Failures})
+	}
+	if dib.Ref != "" {
+		return json.Marshal(struct{ Ref string }{dib.Ref})
+	}
+	type parsedIdBytes ParsedIdBytes
+	return json.Marshal((*parsedIdBytes)(dib))
+}
+
+func (dib *ParsedIdBytes) DecodeIdBytes(decoder *Decoder, idBytes []byte) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	if decoder.skipCertificates() {
+		raw := decoder.bytes(idBytes)
+		dib.Raw = &raw
+		logger.Debug("DecodedIdBytes", "value", dib)
+		return nil
+	}
+
+	// a certificate that was decoded before is only referred to
+	var fingerprint string
+	if decoder.dedupsIdentities() {
+		fingerprint = certificateFingerprint(idBytes)
+		if _, ok := decoder.identities[fingerprint]; ok {
+			dib.Ref = fingerprint
+			logger.Debug("DecodedIdBytes", "value", dib)
+			return nil
+		}
+	}
+
+	cert, err := parseCertificate(idBytes)
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		raw := decoder.bytes(idBytes)
+		dib.Raw = &raw
+		return decoder.fail(&dib.Failures, decodeError("", "x509.Certificate", idBytes, err))
+	}
+
+	if !decoder.dedupsIdentities() {
+		return dib.decodeCertificate(decoder, cert)
+	}
+
+	// the entry of the certificate is only registered once it is decoded, with the failures of a lenient Decoder
+	decodedIdBytes := &ParsedIdBytes{}
+	err = decodedIdBytes.decodeCertificate(decoder, cert)
+	if err != nil {
+		return err
+	}
+	decoder.identities[fingerprint] = decodedIdBytes
+	dib.Ref = fingerprint
+
+	logger.Debug("DecodedIdBytes", "value", dib)
+
+	return nil
+}
+
+// decodeCertificate fills the fields of dib from cert
+func (dib *ParsedIdBytes) decodeCertificate(decoder *Decoder, cert *x509.Certificate) error {
+	logger := decoder.logger()
+
+	dib.Signature = decoder.bytes(cert.Signature)
+	dib.SignatureAlgorithm = cert.SignatureAlgorithm.String()
+	dib.PublicKeyAlgorithm = cert.PublicKeyAlgorithm.String()
+
+	decodedPublicKey := &ParsedPublicKey{}
+	err := decodedPublicKey.DecodePublicKey(decoder, cert)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("PublicKey", err)
+	}
+	dib.PublicKey = decodedPublicKey
+
+	dib.Version = cert.Version
+	dib.SerialNumber = cert.SerialNumber
+	dib.Issuer = cert.Issuer.String()
+	dib.Subject = cert.Subject.String()
+	dib.NotBefore = cert.NotBefore
+	dib.NotAfter = cert.NotAfter
+	dib.KeyUsage = cert.KeyUsage
+
+	decodedExtensions := []ParsedExtension{}
+	for _, extension := range cert.Extensions {
+		decodedExtension := ParsedExtension{}
+		decodedExtension.Id = extension.Id.String()
+		decodedExtension.Critical = extension.Critical
+		decodedExtension.Value = decoder.bytes(extension.Value)
+		decodedExtension.Name = extensionName[decodedExtension.Id]
+		decodedExtensions = append(decodedExtensions, decodedExtension)
+
+		if extension.Id.Equal(fabricAttributesOID) {
+			attributes, err := decodeFabricAttributes(extension.Value)
+			if err != nil {
+				logger.Warn("cannot decode", "err", err)
+				err = decoder.fail(&dib.Failures, decodeError("Attributes", "attrmgr.Attributes", extension.Value, err))
+				if err != nil {
+					return err
+				}
+			}
+			dib.Attributes = attributes
+		}
+	}
+	dib.Extensions = decodedExtensions
+	dib.BasicConstraintsValid = cert.BasicConstraintsValid
+	dib.IsCA = cert.IsCA
+	dib.MaxPathLen = cert.MaxPathLen
+	dib.MaxPathLenZero = cert.MaxPathLenZero
+	dib.SubjectKeyId = decoder.bytes(cert.SubjectKeyId)
+	dib.AuthorityKeyId = decoder.bytes(cert.AuthorityKeyId)
+
+	dib.DNSNames = cert.DNSNames
+	dib.EmailAddresses = cert.EmailAddresses
+	for _, ipAddress := range cert.IPAddresses {
+		dib.IPAddresses = append(dib.IPAddresses, ipAddress.String())
+	}
+	for _, uri := range cert.URIs {
+		dib.URIs = append(dib.URIs, uri.String())
+	}
+	if len(cert.ExtKeyUsage) > 0 || len(cert.UnknownExtKeyUsage) > 0 {
+		dib.ExtKeyUsage = extKeyUsages(cert)
+	}
+	dib.SubjectKeyIdHex = hex.EncodeToString(cert.SubjectKeyId)
+	dib.AuthorityKeyIdHex = hex.EncodeToString(cert.AuthorityKeyId)
+	dib.CRLDistributionPoints = cert.CRLDistributionPoints
+
+	if decoder.certificateDetail() {
+		dib.PEM = certificatePEM(cert)
+		dib.SHA1Fingerprint, dib.SHA256Fingerprint = certificateFingerprints(cert)
+		dib.SignatureAlgorithmOID, err = signatureAlgorithmOID(cert)
+		if err != nil {
+			logger.Warn("cannot decode", "err", err)
+			err = decoder.fail(&dib.Failures, decodeError("SignatureAlgorithmOID", "x509.Certificate", cert.Raw, err))
+			if err != nil {
+				return err
+			}
+		}
+		dib.SubjectName = newParsedName(cert.Subject)
+		dib.IssuerName = newParsedName(cert.Issuer)
+		dib.SubjectAltNames = subjectAltNames(cert)
+	}
+
+	logger.Debug("DecodedIdBytes", "value", dib)
+
+	return nil
+}
+
+type ParsedExtension struct {
+	// pkix.Extension
+	Id       string      //func (pkix.Extension).Id() asn1.ObjectIdentifier
+	Critical bool        //func (pkix.Extension).Critical() bool
+	Value    ParsedBytes //func (pkix.Extension).Value() []byte
+	Name     string      `json:",omitempty"` //of the well-known extensions, e.g. SubjectAltName
+}
+
+type ParsedData struct {
+	// *peer.Transaction
+	Actions []*ParsedTransactionAction //func (*peer.Transaction).GetActions() []*peer.TransactionAction
+}
+
+func (dd *ParsedData) DecodeData(decoder *Decoder, data *peer.Transaction) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedTransactionActions := []*ParsedTransactionAction{}
+	for i, action := range data.GetActions() {
+		decodedTransactionAction := &ParsedTransactionAction{}
+		err := decodedTransactionAction.DecodeTransactionAction(decoder, action)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Actions[%d]", i), err)
+		}
+		decodedTransactionActions = append(decodedTransactionActions, decodedTransactionAction)
+	}
+	dd.Actions = decodedTransactionActions
+
+	logger.Debug("DecodedData", "value", dd)
+
+	return nil
+}
+
+type ParsedTransactionAction struct {
+	// *peer.TransactionAction
+	Header   *ParsedTransactionActionHeader //func (*peer.TransactionAction).GetHeader() []byte
+	Payload  *ParsedChaincodeActionPayload  //func (*peer.TransactionAction).GetPayload() []byte
+	Failures []*ParsedFailure               `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dta *ParsedTransactionAction) DecodeTransactionAction(decoder *Decoder, action *peer.TransactionAction) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	transactionActionHeader := &common.SignatureHeader{}
+	err := transactionActionHeader.XXX_Unmarshal(action.GetHeader())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dta.Failures, unmarshalError("Header", transactionActionHeader, action.GetHeader(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedTransactionActionHeader := &ParsedTransactionActionHeader{}
+	err = decodedTransactionActionHeader.DecodeTransactionActionHeader(decoder, transactionActionHeader)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Header", err)
+	}
+	dta.Header = decodedTransactionActionHeader
+
+	chaincodeActionPayload := &peer.ChaincodeActionPayload{}
+	err = chaincodeActionPayload.XXX_Unmarshal(action.GetPayload())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dta.Failures, unmarshalError("Payload", chaincodeActionPayload, action.GetPayload(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedChaincodeActionPayload := &ParsedChaincodeActionPayload{}
+	err = decodedChaincodeActionPayload.DecodeChaincodeActionPayload(decoder, chaincodeActionPayload)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Payload", err)
+	}
+	dta.Payload = decodedChaincodeActionPayload
+
+	logger.Debug("DecodedTransactionAction", "value", dta)
+
+	return nil
+}
+
+type ParsedTransactionActionHeader struct {
+	// *common.SignatureHeader
+	Creator  *ParsedSerializedIdentity //func (*common.SignatureHeader).GetCreator() []byte
+	Nonce    ParsedBytes               //func (*common.SignatureHeader).GetNonce() []byte
+	Failures []*ParsedFailure          `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dsh *ParsedTransactionActionHeader) DecodeTransactionActionHeader(decoder *Decoder, transactionActionHeader *common.SignatureHeader) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	serializedIdentity := &msp.SerializedIdentity{}
+	err := serializedIdentity.XXX_Unmarshal(transactionActionHeader.GetCreator())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dsh.Failures, unmarshalError("Creator", serializedIdentity, transactionActionHeader.GetCreator(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedSerializedIdentity := &ParsedSerializedIdentity{}
+	err = decodedSerializedIdentity.DecodeSerializedIdentity(decoder, serializedIdentity)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Creator", err)
+	}
+	dsh.Creator = decodedSerializedIdentity
+
+	dsh.Nonce = decoder.bytes(transactionActionHeader.GetNonce())
+
+	logger.Debug("DecodedTransactionActionHeader", "value", dsh)
+
+	return nil
+}
+
+type ParsedChaincodeActionPayload struct {
+	// *peer.ChaincodeActionPayload
+	ChaincodeProposalPayload *ParsedChaincodeProposalPayload //func (*peer.ChaincodeActionPayload).GetChaincodeProposalPayload() []byte
+	Action                   *ParsedChaincodeEndorsedAction  //func (*peer.ChaincodeActionPayload).GetAction() *peer.ChaincodeEndorsedAction
+	Failures                 []*ParsedFailure                `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dcap *ParsedChaincodeActionPayload) DecodeChaincodeActionPayload(decoder *Decoder, chaincodeActionPayload *peer.ChaincodeActionPayload) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	chaincodeProposalPayload := &peer.ChaincodeProposalPayload{}
+	err := chaincodeProposalPayload.XXX_Unmarshal(chaincodeActionPayload.GetChaincodeProposalPayload())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dcap.Failures, unmarshalError("ChaincodeProposalPayload", chaincodeProposalPayload, chaincodeActionPayload.GetChaincodeProposalPayload(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedChaincodeProposalPayload := &ParsedChaincodeProposalPayload{}
+	err = decodedChaincodeProposalPayload.DecodeChaincodeProposalPayload(decoder, chaincodeProposalPayload)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChaincodeProposalPayload", err)
+	}
+	dcap.ChaincodeProposalPayload = decodedChaincodeProposalPayload
+
+	decodedChaincodeEndorsedAction := &ParsedChaincodeEndorsedAction{}
+	err = decodedChaincodeEndorsedAction.DecodeChaincodeEndorsedAction(decoder, chaincodeActionPayload.GetAction())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Action", err)
+	}
+	dcap.Action = decodedChaincodeEndorsedAction
+
+	logger.Debug("DecodedChaincodeActionPayload", "value", dcap)
+
+	return nil
+}
+
+type ParsedChaincodeProposalPayload struct {
+	// *peer.ChaincodeProposalPayload
+	Input        *ParsedChaincodeInvocationSpec //func (*peer.ChaincodeProposalPayload).GetInput() []byte
+	TransientMap map[string]ParsedBytes         //func (*peer.ChaincodeProposalPayload).GetTransientMap() map[string][]byte
+	Failures     []*ParsedFailure               `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dcpp *ParsedChaincodeProposalPayload) DecodeChaincodeProposalPayload(decoder *Decoder, chaincodeProposalPayload *peer.ChaincodeProposalPayload) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	chaincodeInvocationSpec := &peer.ChaincodeInvocationSpec{}
+	err := chaincodeInvocationSpec.XXX_Unmarshal(chaincodeProposalPayload.GetInput())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dcpp.Failures, unmarshalError("Input", chaincodeInvocationSpec, chaincodeProposalPayload.GetInput(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedChaincodeInvocationSpec := &ParsedChaincodeInvocationSpec{}
+	err = decodedChaincodeInvocationSpec.DecodeChaincodeInvocationSpec(decoder, chaincodeInvocationSpec)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Input", err)
+	}
+	dcpp.Input = decodedChaincodeInvocationSpec
+
+	dcpp.TransientMap = decoder.bytesMap(chaincodeProposalPayload.GetTransientMap())
+
+	logger.Debug("DecodedChaincodeProposalPayload", "value", dcpp)
+
+	return nil
+}
+
+type ParsedChaincodeInvocationSpec struct {
+	// *peer.ChaincodeInvocationSpec
+	ChaincodeSpec *ParsedChaincodeSpec //func (*peer.ChaincodeInvocationSpec).GetChaincodeSpec() *peer.ChaincodeSpec
+}
+
+func (dcis *ParsedChaincodeInvocationSpec) DecodeChaincodeInvocationSpec(decoder *Decoder, chaincodeInvocationSpec *peer.ChaincodeInvocationSpec) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedChaincodeSpec := &ParsedChaincodeSpec{}
+	err := decodedChaincodeSpec.DecodeChaincodeSpec(decoder, chaincodeInvocationSpec.GetChaincodeSpec())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChaincodeSpec", err)
+	}
+	dcis.ChaincodeSpec = decodedChaincodeSpec
+
+	logger.Debug("DecodedChaincodeInvocationSpec", "value", dcis)
+
+	return nil
+}
+
+type ParsedChaincodeSpec struct {
+	// *peer.ChaincodeSpec
+	Type        ParsedEnum            //func (*peer.ChaincodeSpec).GetType() ChaincodeSpec_Type
+	ChaincodeId *ParsedChaincodeId    //func (*peer.ChaincodeSpec).GetChaincodeId() *peer.ChaincodeID
+	Input       *ParsedChaincodeInput //func (*peer.ChaincodeSpec).GetInput() *peer.ChaincodeInput
+	Timeout     int32                 //func (*peer.ChaincodeSpec).GetTimeout() int32
+}
+
+func (dcs *ParsedChaincodeSpec) DecodeChaincodeSpec(decoder *Decoder, chaincodeSpec *peer.ChaincodeSpec) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dcs.Type = chaincodeTypeEnum(chaincodeSpec.GetType())
+
+	decodedChaincodeId := &ParsedChaincodeId{}
+	err := decodedChaincodeId.DecodeChaincodeId(decoder, chaincodeSpec.GetChaincodeId())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChaincodeId", err)
+	}
+	dcs.ChaincodeId = decodedChaincodeId
+
+	decodedChaincodeInput := &ParsedChaincodeInput{}
+	err = decodedChaincodeInput.DecodeChaincodeInput(decoder, chaincodeSpec.GetInput())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Input", err)
+	}
+	dcs.Input = decodedChaincodeInput
+
+	dcs.Timeout = chaincodeSpec.GetTimeout()
+
+	logger.Debug("DecodedChaincodeSpec", "value", dcs)
+
+	return nil
+}
+
+type ParsedChaincodeId struct {
+	// *peer.ChaincodeID
+	Name    string //func (*peer.ChaincodeID).GetName() string
+	Version string //func (*peer.ChaincodeID).GetVersion() string
+	Path    string //func (*peer.ChaincodeID).GetPath() string
+}
+
+func (dci *ParsedChaincodeId) DecodeChaincodeId(decoder *Decoder, chaincodeId *peer.ChaincodeID) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dci.Name = chaincodeId.GetName()
+	dci.Version = chaincodeId.GetVersion()
+	dci.Path = chaincodeId.GetPath()
+
+	logger.Debug("DecodedChaincodeId", "value", dci)
+
+	return nil
+}
+
+type ParsedChaincodeInput struct {
+	// *peer.ChaincodeInput
+	Args        *ParsedArgs            //func (*peer.ChaincodeInput).GetArgs() [][]byte
+	Decorations map[string]ParsedBytes //func (*peer.ChaincodeInput).GetDecorations() map[string][]byte
+	IsInit      bool                   //func (*peer.ChaincodeInput).GetIsInit() bool
+}
+
+func (dci *ParsedChaincodeInput) DecodeChaincodeInput(decoder *Decoder, chaincodeInput *peer.ChaincodeInput) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedArgs := &ParsedArgs{}
+	err := decodedArgs.DecodeArgs(decoder, chaincodeInput.GetArgs())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Args", err)
+	}
+	dci.Args = decodedArgs
+
+	dci.Decorations = decoder.bytesMap(chaincodeInput.GetDecorations())
+	dci.IsInit = chaincodeInput.GetIsInit()
+
+	logger.Debug("DecodedChaincodeInput", "value", dci)
+
+	return nil
+}
+
+type ParsedArgs struct {
+	Args []ParsedBytes
+}
+
+func (da *ParsedArgs) DecodeArgs(decoder *Decoder, args [][]byte) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	da.Args = decoder.bytesList(args)
+
+	logger.Debug("DecodedArgs", "value", da)
+
+	return nil
+}
+
+type ParsedChaincodeEndorsedAction struct {
+	// *peer.ChaincodeEndorsedAction
+	ProposalResponsePayload *ParsedProposalResponsePayload //func (*peer.ChaincodeEndorsedAction).GetProposalResponsePayload() []byte
+	Endorsements            []*ParsedEndorsement           //func (*peer.ChaincodeEndorsedAction).GetEndorsements() []*peer.Endorsement
+	Failures                []*ParsedFailure               `json:",omitempty"` //parts a lenient Decoder could not decode
+
+	EndorsementPolicy *ParsedPolicyEvaluation `json:",omitempty"` //the EndorsementPolicy of the Decoder evaluated against Endorsements
+}
+
+func (dcea *ParsedChaincodeEndorsedAction) DecodeChaincodeEndorsedAction(decoder *Decoder, chaincodeEndorsedAction *peer.ChaincodeEndorsedAction) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	proposalResponsePayload := &peer.ProposalResponsePayload{}
+	err := proposalResponsePayload.XXX_Unmarshal(chaincodeEndorsedAction.GetProposalResponsePayload())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dcea.Failures, unmarshalError("ProposalResponsePayload", proposalResponsePayload, chaincodeEndorsedAction.GetProposalResponsePayload(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedProposalResponsePayload := &ParsedProposalResponsePayload{}
+	err = decodedProposalResponsePayload.DecodeProposalResponsePayload(decoder, proposalResponsePayload)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ProposalResponsePayload", err)
+	}
+	dcea.ProposalResponsePayload = decodedProposalResponsePayload
+
+	decodedEndorsements := []*ParsedEndorsement{}
+	for i, endorsement := range chaincodeEndorsedAction.GetEndorsements() {
+		decodedEndorsement := &ParsedEndorsement{}
+		err := decodedEndorsement.DecodeEndorsement(decoder, endorsement)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Endorsements[%d]", i), err)
+		}
+		// the endorser signed the proposal response, which the endorsement itself does not carry
+		if decoder.verifySignatures() {
+			decodedEndorsement.SignatureValid, decodedEndorsement.SignatureReason = verifyEndorsementSignature(endorsement, chaincodeEndorsedAction.GetProposalResponsePayload())
+		}
+		decodedEndorsements = append(decodedEndorsements, decodedEndorsement)
+	}
+	dcea.Endorsements = decodedEndorsements
+
+	if decoder.evaluatesEndorsementPolicy() {
+		dcea.EndorsementPolicy = decoder.evaluateEndorsementPolicy(chaincodeEndorsedAction.GetEndorsements(), decodedEndorsements, chaincodeEndorsedAction.GetProposalResponsePayload())
+	}
+
+	logger.Debug("DecodedChaincodeEndorsedAction", "value", dcea)
+
+	return nil
+}
+
+type ParsedProposalResponsePayload struct {
+	// *peer.ProposalResponsePayload
+	ProposalHash ParsedBytes            //func (*peer.ProposalResponsePayload).GetProposalHash() []byte
+	Extension    *ParsedChaincodeAction //func (*peer.ProposalResponsePayload).GetExtension() []byte
+	Failures     []*ParsedFailure       `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dprp *ParsedProposalResponsePayload) DecodeProposalResponsePayload(decoder *Decoder, proposalResponsePayload *peer.ProposalResponsePayload) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dprp.ProposalHash = decoder.bytes(proposalResponsePayload.GetProposalHash())
+
+	chaincodeAction := &peer.ChaincodeAction{}
+	err := chaincodeAction.XXX_Unmarshal(proposalResponsePayload.GetExtension())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dprp.Failures, unmarshalError("Extension", chaincodeAction, proposalResponsePayload.GetExtension(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedChaincodeAction := &ParsedChaincodeAction{}
+	err = decodedChaincodeAction.DecodeChaincodeAction(decoder, chaincodeAction)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Extension", err)
+	}
+	dprp.Extension = decodedChaincodeAction
+
+	logger.Debug("DecodedProposalResponsePayload", "value", dprp)
+
+	return nil
+}
+
+type ParsedChaincodeAction struct {
+	// *peer.ChaincodeAction
+	Results     *ParsedReadWriteSet   //func (*peer.ChaincodeAction).GetResults() []byte
+	Events      *ParsedChaincodeEvent //func (*peer.ChaincodeAction).GetEvents() []byte
+	Response    *ParsedResponse       //func (*peer.ChaincodeAction).GetResponse() *peer.Response
+	ChaincodeId *ParsedChaincodeId    //func (*peer.ChaincodeAction).GetChaincodeId() *peer.ChaincodeID
+	Failures    []*ParsedFailure      `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dca *ParsedChaincodeAction) DecodeChaincodeAction(decoder *Decoder, chaincodeAction *peer.ChaincodeAction) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	txReadWriteSet := &rwset.TxReadWriteSet{}
+	err := txReadWriteSet.XXX_Unmarshal(chaincodeAction.GetResults())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dca.Failures, unmarshalError("Results", txReadWriteSet, chaincodeAction.GetResults(), err))
+		if err != nil {
+			return err
+		}
+	}
+	decodedReadWriteSet := &ParsedReadWriteSet{}
+	err = decodedReadWriteSet.DecodeReadWriteSet(decoder, txReadWriteSet)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Results", err)
+	}
+	dca.Results = decodedReadWriteSet
+
+	chaincodeEvent := &peer.ChaincodeEvent{}
+	err = chaincodeEvent.XXX_Unmarshal(chaincodeAction.GetEvents())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dca.Failures, unmarshalError("Events", chaincodeEvent, chaincodeAction.GetEvents(), err))
+		if err != nil {
+			return err
+		}
+	}
+	decodedChaincodeEvent := &ParsedChaincodeEvent{}
+	err = decodedChaincodeEvent.DecodeChaincodeEvent(decoder, chaincodeEvent)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Events", err)
+	}
+	dca.Events = decodedChaincodeEvent
+
+	decodedResponse := &ParsedResponse{}
+	err = decodedResponse.DecodeResponse(decoder, chaincodeAction.GetResponse())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Response", err)
+	}
+	dca.Response = decodedResponse
+
+	decodedChaincodeId := &ParsedChaincodeId{}
+	err = decodedChaincodeId.DecodeChaincodeId(decoder, chaincodeAction.GetChaincodeId())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("ChaincodeId", err)
+	}
+	dca.ChaincodeId = decodedChaincodeId
+
+	logger.Debug("DecodedChaincodeAction", "value", dca)
+
+	return nil
+}
+
+type ParsedReadWriteSet struct {
+	// *rwset.TxReadWriteSet
+	DataModel ParsedEnum              //func (*rwset.TxReadWriteSet).GetDataModel() rwset.TxReadWriteSet_DataModel
+	NsRwset   []*ParsedNsReadWriteSet //func (*rwset.TxReadWriteSet).GetNsRwset() []*rwset.NsReadWriteSet
+}
+
+func (drws *ParsedReadWriteSet) DecodeReadWriteSet(decoder *Decoder, txReadWriteSet *rwset.TxReadWriteSet) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	drws.DataModel = dataModelEnum(txReadWriteSet.GetDataModel())
+
+	decodedNsReadWriteSets := []*ParsedNsReadWriteSet{}
+	for i, nsReadWriteSet := range txReadWriteSet.GetNsRwset() {
+		decodedNsReadWriteSet := &ParsedNsReadWriteSet{}
+		err := decodedNsReadWriteSet.DecodeNsReadWriteSet(decoder, nsReadWriteSet)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("NsRwset[%d]", i), err)
+		}
+		decodedNsReadWriteSets = append(decodedNsReadWriteSets, decodedNsReadWriteSet)
+	}
+	drws.NsRwset = decodedNsReadWriteSets
+
+	logger.Debug("DecodedReadWriteSet", "value", drws)
+
+	return nil
+}
+
+type ParsedNsReadWriteSet struct {
+	// *rwset.NsReadWriteSet
+	Namespace             string                                //func (*rwset.NsReadWriteSet).GetNamespace() string
+	Rwset                 *ParsedKVRWSet                        //func (*rwset.NsReadWriteSet).GetRwset() []byte
+	CollectionHashedRwset []*ParsedCollectionHashedReadWriteSet //func (*rwset.NsReadWriteSet).GetCollectionHashedRwset() []*rwset.CollectionHashedReadWriteSet
+	Failures              []*ParsedFailure                      `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dnrws *ParsedNsReadWriteSet) DecodeNsReadWriteSet(decoder *Decoder, nsReadWriteSet *rwset.NsReadWriteSet) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dnrws.Namespace = nsReadWriteSet.GetNamespace()
+
+	kvRwset := &kvrwset.KVRWSet{}
+	err := kvRwset.XXX_Unmarshal(nsReadWriteSet.GetRwset())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dnrws.Failures, unmarshalError("Rwset", kvRwset, nsReadWriteSet.GetRwset(), err))
+		if err != nil {
+			return err
+		}
+	}
+	decodedKVRWSet := &ParsedKVRWSet{}
+	err = decodedKVRWSet.DecodeKVRWSet(decoder, kvRwset)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Rwset", err)
+	}
+	dnrws.Rwset = decodedKVRWSet
+
+	decodedCollectionHashedReadWriteSets := []*ParsedCollectionHashedReadWriteSet{}
+	for i, collectionHashedReadWriteSet := range nsReadWriteSet.GetCollectionHashedRwset() {
+		decodedCollectionHashedReadWriteSet := &ParsedCollectionHashedReadWriteSet{}
+		err := decodedCollectionHashedReadWriteSet.DecodeCollectionHashedReadWriteSet(decoder, collectionHashedReadWriteSet)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("CollectionHashedRwset[%d]", i), err)
+		}
+		decodedCollectionHashedReadWriteSets = append(decodedCollectionHashedReadWriteSets, decodedCollectionHashedReadWriteSet)
+	}
+	dnrws.CollectionHashedRwset = decodedCollectionHashedReadWriteSets
+
+	logger.Debug("DecodedNsReadWriteSet", "value", dnrws)
+
+	return nil
+}
+
+type ParsedKVRWSet struct {
+	// *kvrwset.KVRWSet
+	Reads            []*ParsedKVRead          //func (*kvrwset.KVRWSet).GetReads() []*kvrwset.KVRead
+	RangeQueriesInfo []*ParsedRangeQueryInfo  //func (*kvrwset.KVRWSet).GetRangeQueriesInfo() []*kvrwset.RangeQueryInfo
+	Writes           []*ParsedKVWrite         //func (*kvrwset.KVRWSet).GetWrites() []*kvrwset.KVWrite
+	MetadataWrites   []*ParsedKVMetadataWrite //func (*kvrwset.KVRWSet).GetMetadataWrite() []*kvrwset.KVMetadataWrite
+}
+
+func (dkrws *ParsedKVRWSet) DecodeKVRWSet(decoder *Decoder, kvRwset *kvrwset.KVRWSet) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedKVReads := []*ParsedKVRead{}
+	for i, kvRead := range kvRwset.GetReads() {
+		decodedKVRead := &ParsedKVRead{}
+		err := decodedKVRead.DecodeKVRead(decoder, kvRead)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Reads[%d]", i), err)
+		}
+		decodedKVReads = append(decodedKVReads, decodedKVRead)
+	}
+	dkrws.Reads = decodedKVReads
+
+	decodedRangeQueryInfos := []*ParsedRangeQueryInfo{}
+	for i, rangeQueryInfo := range kvRwset.GetRangeQueriesInfo() {
+		decodedRangeQueryInfo := &ParsedRangeQueryInfo{}
+		err := decodedRangeQueryInfo.DecodeRangeQueryInfo(decoder, rangeQueryInfo)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("RangeQueriesInfo[%d]", i), err)
+		}
+		decodedRangeQueryInfos = append(decodedRangeQueryInfos, decodedRangeQueryInfo)
+	}
+	dkrws.RangeQueriesInfo = decodedRangeQueryInfos
+
+	decodedKVWrites := []*ParsedKVWrite{}
+	for i, kvWrite := range kvRwset.GetWrites() {
+		decodedKVWrite := &ParsedKVWrite{}
+		err := decodedKVWrite.DecodeKVWrite(decoder, kvWrite)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Writes[%d]", i), err)
+		}
+		decodedKVWrites = append(decodedKVWrites, decodedKVWrite)
+	}
+	dkrws.Writes = decodedKVWrites
+
+	decodedKVMetadataWrites := []*ParsedKVMetadataWrite{}
+	for i, kvMetadataWrite := range kvRwset.GetMetadataWrites() {
+		decodedKVMetadataWrite := &ParsedKVMetadataWrite{}
+		err := decodedKVMetadataWrite.DecodeKVMetadataWrite(decoder, kvMetadataWrite)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("MetadataWrites[%d]", i), err)
+		}
+		decodedKVMetadataWrites = append(decodedKVMetadataWrites, decodedKVMetadataWrite)
+	}
+	dkrws.MetadataWrites = decodedKVMetadataWrites
+
+	logger.Debug("DecodedKVRWSet", "value", dkrws)
+
+	return nil
+}
+
+type ParsedKVRead struct {
+	// *kvrwset.KVRead
+	Key     string         //func (*kvrwset.KVRead).GetKey() string
+	Version *ParsedVersion //func (*kvrwset.KVRead).GetVersion() *kvrwset.Version
+}
+
+func (dkr *ParsedKVRead) DecodeKVRead(decoder *Decoder, kvRead *kvrwset.KVRead) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkr.Key = kvRead.GetKey()
+
+	decodedVersion := &ParsedVersion{}
+	err := decodedVersion.DecodeVersion(decoder, kvRead.GetVersion())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Version", err)
+	}
+	dkr.Version = decodedVersion
+
+	logger.Debug("DecodedKVRead", "value", dkr)
+
+	return nil
+}
+
+type ParsedVersion struct {
+	// *kvrwset.Version
+	BlockNum uint64 //func (*kvrwset.Version).GetBlockNum() uint64
+	TxNum    uint64 //func (*kvrwset.Version).GetTxNum() uint64
+}
+
+func (dv *ParsedVersion) DecodeVersion(decoder *Decoder, version *kvrwset.Version) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dv.BlockNum = version.GetBlockNum()
+	dv.TxNum = version.GetTxNum()
+
+	logger.Debug("DecodedVersion", "value", dv)
+
+	return nil
+}
+
+type ParsedRangeQueryInfo struct {
+	// *kvrwset.RangeQueryInfo
+	StartKey     string //func (*kvrwset.RangeQueryInfo).GetStartKey() string
+	EndKey       string //func (*kvrwset.RangeQueryInfo).GetEndKey() string
+	ItrExhausted bool   //func (*kvrwset.RangeQueryInfo).GetItrExhausted() bool
+	// ReadsInfo *Decoded //func (*kvrwset.RangeQueryInfo).GetReadsInfo() kvrwset.isRangeQueryInfo_ReadsInfo
+}
+
+func (drqi *ParsedRangeQueryInfo) DecodeRangeQueryInfo(decoder *Decoder, rangeQueryInfo *kvrwset.RangeQueryInfo) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	drqi.StartKey = rangeQueryInfo.GetStartKey()
+	drqi.EndKey = rangeQueryInfo.GetEndKey()
+	drqi.ItrExhausted = rangeQueryInfo.GetItrExhausted()
+
+	logger.Debug("DecodedRangeQueryInfo", "value", drqi)
+
+	return nil
+}
+
+type ParsedKVWrite struct {
+	// *kvrwset.KVWrite
+	Key      string      //func (*kvrwset.KVWrite).GetKey() string
+	IsDelete bool        //func (*kvrwset.KVWrite).GetIsDelete() bool
+	Value    ParsedBytes //func (*kvrwset.KVWrite).GetValue() []byte
+}
+
+func (dkw *ParsedKVWrite) DecodeKVWrite(decoder *Decoder, kvWrite *kvrwset.KVWrite) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkw.Key = kvWrite.GetKey()
+	dkw.IsDelete = kvWrite.GetIsDelete()
+	dkw.Value = decoder.bytes(kvWrite.GetValue())
+
+	logger.Debug("DecodedKVWrite", "value", dkw)
+
+	return nil
+}
+
+type ParsedKVMetadataWrite struct {
+	// *kvrwset.KVMetadataWrite
+	Key     string                   //func (*kvrwset.KVMetadataWrite).GetKey() string
+	Entries []*ParsedKVMetadataEntry //func (*kvrwset.KVMetadataWrite).GetEntries() []*kvrwset.KVMetadataEntry
+}
+
+func (dkmw *ParsedKVMetadataWrite) DecodeKVMetadataWrite(decoder *Decoder, kvMetadataWrite *kvrwset.KVMetadataWrite) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkmw.Key = kvMetadataWrite.GetKey()
+
+	decodedKVMetadataEntries := []*ParsedKVMetadataEntry{}
+	for i, kvMetadataEntry := range kvMetadataWrite.GetEntries() {
+		decodedKVMetadataEntry := &ParsedKVMetadataEntry{}
+		err := decodedKVMetadataEntry.DecodeKVMetadataEntry(decoder, kvMetadataEntry)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Entries[%d]", i), err)
+		}
+		decodedKVMetadataEntries = append(decodedKVMetadataEntries, decodedKVMetadataEntry)
+	}
+	dkmw.Entries = decodedKVMetadataEntries
+
+	logger.Debug("DecodedKVMetadataWrite", "value", dkmw)
+
+	return nil
+}
+
+type ParsedKVMetadataEntry struct {
+	// *kvrwset.KVMetadataEntry
+	Name  string      //func (*kvrwset.KVMetadataEntry).GetName() string
+	Value ParsedBytes //func (*kvrwset.KVMetadataEntry).GetValue() []byte
+}
+
+func (dkme *ParsedKVMetadataEntry) DecodeKVMetadataEntry(decoder *Decoder, kvMetadataEntry *kvrwset.KVMetadataEntry) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkme.Name = kvMetadataEntry.GetName()
+	dkme.Value = decoder.bytes(kvMetadataEntry.GetValue())
+
+	logger.Debug("DecodedKVMetadataEntry", "value", dkme)
+
+	return nil
+}
+
+type ParsedCollectionHashedReadWriteSet struct {
+	// *rwset.CollectionHashedReadWriteSet
+	CollectionName string             //func (*rwset.CollectionHashedReadWriteSet).GetCollectionName() string
+	HashedRwset    *ParsedHashedRWSet //func (*rwset.CollectionHashedReadWriteSet).GetHashedRwset() []byte
+	PvtRwsetHash   ParsedBytes        //func (*rwset.CollectionHashedReadWriteSet).GetPvtRwsetHash() []byte
+	Failures       []*ParsedFailure   `json:",omitempty"` //parts a lenient Decoder could not decode
+}
+
+func (dchrw *ParsedCollectionHashedReadWriteSet) DecodeCollectionHashedReadWriteSet(decoder *Decoder, collectionHashedReadWriteSet *rwset.CollectionHashedReadWriteSet) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dchrw.CollectionName = collectionHashedReadWriteSet.GetCollectionName()
+
+	hashedRwset := &kvrwset.HashedRWSet{}
+	err := hashedRwset.XXX_Unmarshal(collectionHashedReadWriteSet.GetHashedRwset())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&dchrw.Failures, unmarshalError("HashedRwset", hashedRwset, collectionHashedReadWriteSet.GetHashedRwset(), err))
+		if err != nil {
+			return err
+		}
+	}
+	decodedHashedRWSet := &ParsedHashedRWSet{}
+	err = decodedHashedRWSet.DecodeHashedRWSet(decoder, hashedRwset)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("HashedRwset", err)
+	}
+	dchrw.HashedRwset = decodedHashedRWSet
+
+	dchrw.PvtRwsetHash = decoder.bytes(collectionHashedReadWriteSet.GetPvtRwsetHash())
+
+	logger.Debug("DecodedCollectionHashedReadWriteSet", "value", dchrw)
+
+	return nil
+}
+
+type ParsedHashedRWSet struct {
+	// *kvrwset.HashedRWSet
+	HashedReads    []*ParsedKVReadHash          //func (*kvrwset.HashedRWSet).GetHashedReads() []*kvrwset.KVReadHash
+	HashedWrites   []*ParsedKVWriteHash         //func (*kvrwset.HashedRWSet).GetHashedWrites() []*kvrwset.KVWriteHash
+	MetadataWrites []*ParsedKVMetadataWriteHash //func (*kvrwset.HashedRWSet).GetMetadataWrites() []*kvrwset.KVMetadataWriteHash
+}
+
+func (dhrws *ParsedHashedRWSet) DecodeHashedRWSet(decoder *Decoder, hashedRWSet *kvrwset.HashedRWSet) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	decodedKVReadHashes := []*ParsedKVReadHash{}
+	for i, kvReadHash := range hashedRWSet.GetHashedReads() {
+		decodedKVReadHash := &ParsedKVReadHash{}
+		err := decodedKVReadHash.DecodeKVReadHash(decoder, kvReadHash)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("HashedReads[%d]", i), err)
+		}
+		decodedKVReadHashes = append(decodedKVReadHashes, decodedKVReadHash)
+	}
+	dhrws.HashedReads = decodedKVReadHashes
+
+	decodedKVWriteHashes := []*ParsedKVWriteHash{}
+	for i, kvWriteHash := range hashedRWSet.GetHashedWrites() {
+		decodedKVWriteHash := &ParsedKVWriteHash{}
+		err := decodedKVWriteHash.DecodeKVWriteHash(decoder, kvWriteHash)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("HashedWrites[%d]", i), err)
+		}
+		decodedKVWriteHashes = append(decodedKVWriteHashes, decodedKVWriteHash)
+	}
+	dhrws.HashedWrites = decodedKVWriteHashes
+
+	decodedKVMetadataWriteHashes := []*ParsedKVMetadataWriteHash{}
+	for i, kvMetadataWriteHash := range hashedRWSet.GetMetadataWrites() {
+		decodedKVMetadataWriteHash := &ParsedKVMetadataWriteHash{}
+		err := decodedKVMetadataWriteHash.DecodeKVMetadataWriteHash(decoder, kvMetadataWriteHash)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("MetadataWrites[%d]", i), err)
+		}
+		decodedKVMetadataWriteHashes = append(decodedKVMetadataWriteHashes, decodedKVMetadataWriteHash)
+	}
+	dhrws.MetadataWrites = decodedKVMetadataWriteHashes
+
+	logger.Debug("DecodedHashedRWSet", "value", dhrws)
+
+	return nil
+}
+
+type ParsedKVReadHash struct {
+	// *kvrwset.KVReadHash
+	KeyHash ParsedBytes    //func (*kvrwset.KVReadHash).GetKeyHash() []byte
+	Version *ParsedVersion //func (*kvrwset.KVReadHash).GetVersion() *kvrwset.Version
+}
+
+func (dkrh *ParsedKVReadHash) DecodeKVReadHash(decoder *Decoder, kvReadHash *kvrwset.KVReadHash) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkrh.KeyHash = decoder.bytes(kvReadHash.GetKeyHash())
+
+	decodedVersion := &ParsedVersion{}
+	err := decodedVersion.DecodeVersion(decoder, kvReadHash.GetVersion())
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Version", err)
+	}
+	dkrh.Version = decodedVersion
+
+	logger.Debug("DecodedKVReadHash", "value", dkrh)
+
+	return nil
+}
+
+type ParsedKVWriteHash struct {
+	// *kvrwset.KVWriteHash
+	KeyHash   ParsedBytes //func (*kvrwset.KVWriteHash).GetKeyHash() []byte
+	IsDelete  bool        //func (*kvrwset.KVWriteHash).GetIsDelete() bool
+	ValueHash ParsedBytes //func (*kvrwset.KVWriteHash).GetValueHash() []byte
+	IsPurge   bool        //func (*kvrwset.KVWriteHash).GetIsPurge() bool
+}
+
+func (dkwh *ParsedKVWriteHash) DecodeKVWriteHash(decoder *Decoder, kvWriteHash *kvrwset.KVWriteHash) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkwh.KeyHash = decoder.bytes(kvWriteHash.GetKeyHash())
+	dkwh.IsDelete = kvWriteHash.GetIsDelete()
+	dkwh.ValueHash = decoder.bytes(kvWriteHash.GetValueHash())
+	dkwh.IsPurge = kvWriteHash.GetIsPurge()
+
+	logger.Debug("DecodedKVWriteHash", "value", dkwh)
+
+	return nil
+}
+
+type ParsedKVMetadataWriteHash struct {
+	// *kvrwset.KVMetadataWriteHash
+	KeyHash ParsedBytes              //func (*kvrwset.KVMetadataWriteHash).GetKeyHash() []byte
+	Entries []*ParsedKVMetadataEntry //func (*kvrwset.KVMetadataWriteHash).GetEntries() []*kvrwset.KVMetadataEntry
+}
+
+func (dkmwh *ParsedKVMetadataWriteHash) DecodeKVMetadataWriteHash(decoder *Decoder, kvMetadataWriteHash *kvrwset.KVMetadataWriteHash) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dkmwh.KeyHash = decoder.bytes(kvMetadataWriteHash.GetKeyHash())
+
+	decodedKVMetadataEntries := []*ParsedKVMetadataEntry{}
+	for i, kvMetadataEntry := range kvMetadataWriteHash.GetEntries() {
+		decodedKVMetadataEntry := &ParsedKVMetadataEntry{}
+		err := decodedKVMetadataEntry.DecodeKVMetadataEntry(decoder, kvMetadataEntry)
+		if err != nil {
+			logger.Debug("cannot decode", "err", err)
+			return prefixPath(fmt.Sprintf("Entries[%d]", i), err)
+		}
+		decodedKVMetadataEntries = append(decodedKVMetadataEntries, decodedKVMetadataEntry)
+	}
+	dkmwh.Entries = decodedKVMetadataEntries
+
+	logger.Debug("DecodedKVMetadataWriteHash", "value", dkmwh)
+
+	return nil
+}
+
+type ParsedChaincodeEvent struct {
+	// *peer.ChaincodeEvent
+	ChaincodeId string      //func (*peer.ChaincodeEvent).GetChaincodeId() string
+	TxId        string      //func (*peer.ChaincodeEvent).GetTxId() string
+	EventName   string      //func (*peer.ChaincodeEvent).GetEventName() string
+	Payload     ParsedBytes //func (*peer.ChaincodeEvent).GetPayload() []byte
+}
+
+func (dce *ParsedChaincodeEvent) DecodeChaincodeEvent(decoder *Decoder, chaincodeEvent *peer.ChaincodeEvent) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dce.ChaincodeId = chaincodeEvent.GetChaincodeId()
+	dce.TxId = chaincodeEvent.GetTxId()
+	dce.EventName = chaincodeEvent.GetEventName()
+	dce.Payload = decoder.bytes(chaincodeEvent.GetPayload())
+
+	logger.Debug("DecodedChaincodeEvent", "value", dce)
+
+	return nil
+}
+
+type ParsedResponse struct {
+	// *peer.Response
+	Status  ParsedEnum  //func (*peer.Response).GetStatus() int32
+	Message string      //func (*peer.Response).GetMessage() string
+	Payload ParsedBytes //func (*peer.Response).GetPayload() []byte
+}
+
+func (dr *ParsedResponse) DecodeResponse(decoder *Decoder, response *peer.Response) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	dr.Status = responseStatusEnum(response.GetStatus())
+	dr.Message = response.GetMessage()
+	dr.Payload = decoder.bytes(response.GetPayload())
+
+	logger.Debug("DecodedResponse", "value", dr)
+
+	return nil
+}
+
+type ParsedEndorsement struct {
+	// *peer.Endorsement
+	Endorser  *ParsedSerializedIdentity //func (*peer.Endorsement).GetEndorser() []byte
+	Signature ParsedBytes               //func (*peer.Endorsement).GetSignature() []byte
+	Failures  []*ParsedFailure          `json:",omitempty"` //parts a lenient Decoder could not decode
+
+	SignatureValid  *bool  `json:",omitempty"` //whether Signature is the endorser's over the ProposalResponsePayload and Endorser, from a Decoder that verifies signatures
+	SignatureReason string `json:",omitempty"` //why Signature is not valid
+}
+
+func (de *ParsedEndorsement) DecodeEndorsement(decoder *Decoder, endorsement *peer.Endorsement) error {
+	logger := decoder.logger()
+
+	decoder, expand := decoder.nested()
+	if !expand {
+		return nil
+	}
+
+	serializedIdentity := &msp.SerializedIdentity{}
+	err := serializedIdentity.XXX_Unmarshal(endorsement.GetEndorser())
+	if err != nil {
+		logger.Warn("cannot decode", "err", err)
+		err = decoder.fail(&de.Failures, unmarshalError("Endorser", serializedIdentity, endorsement.GetEndorser(), err))
+		if err != nil {
+			return err
+		}
+	}
+
+	decodedSerializedIdentity := &ParsedSerializedIdentity{}
+	err = decodedSerializedIdentity.DecodeSerializedIdentity(decoder, serializedIdentity)
+	if err != nil {
+		logger.Debug("cannot decode", "err", err)
+		return prefixPath("Endorser", err)
+	}
+	de.Endorser = decodedSerializedIdentity
+
+	de.Signature = decoder.bytes(endorsement.GetSignature())
+
+	logger.Debug("DecodedEndorsement", "value", de)
+
+	return nil
+}
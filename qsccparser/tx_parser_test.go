@@ -0,0 +1,59 @@
+package qsccparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+func TestDecodeChannelHeaderExtension(t *testing.T) {
+	ca := newTestCA(t, "org1")
+	user1 := ca.signer(t, "Org1MSP", "user1", "client")
+
+	processedTransaction, err := DecodeTransaction((&testTransaction{creator: user1}).marshal(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the chaincode of an endorser transaction is in its channel header already
+	extension, ok := processedTransaction.TransactionEnvelope.Payload.Header.ChannelHeader.Extension.(*ParsedChaincodeHeaderExtension)
+	if !ok {
+		t.Fatalf("Extension = %T, want the ChaincodeHeaderExtension", processedTransaction.TransactionEnvelope.Payload.Header.ChannelHeader.Extension)
+	}
+	if want := (&ParsedChaincodeId{Name: "basic"}); !reflect.DeepEqual(extension.ChaincodeId, want) {
+		t.Errorf("ChaincodeId = %+v, want %+v", extension.ChaincodeId, want)
+	}
+
+	testExtension := func(headerType common.HeaderType, extension []byte) []byte {
+		channelHeader := testMarshal(t, &common.ChannelHeader{Type: int32(headerType), ChannelId: "mychannel", Extension: extension})
+		payload := testMarshal(t, &common.Payload{Header: &common.Header{ChannelHeader: channelHeader}})
+		return testBlock(t, 1, nil, &common.Envelope{Payload: payload})
+	}
+	chaincodeHeaderExtension := testMarshal(t, &peer.ChaincodeHeaderExtension{ChaincodeId: &peer.ChaincodeID{Name: "basic"}})
+
+	t.Run("other header types", func(t *testing.T) {
+		// the extension of other header types is kept as it is
+		decodedBlock, err := DecodeBlock(testExtension(common.HeaderType_MESSAGE, chaincodeHeaderExtension))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := ParsedBytes{Bytes: chaincodeHeaderExtension, Length: len(chaincodeHeaderExtension)}
+		if extension := decodedBlock.Data.Data[0].Payload.Header.ChannelHeader.Extension; !reflect.DeepEqual(extension, want) {
+			t.Errorf("Extension = %+v, want %+v", extension, want)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		block := testExtension(common.HeaderType_ENDORSER_TRANSACTION, []byte{0x0a, 0xff})
+		_, err := DecodeBlock(block)
+		if decodeErr, ok := err.(*DecodeError); !ok || decodeErr.Path != "Data.Data[0].Payload.Header.ChannelHeader.Extension" || decodeErr.Message != "protos.ChaincodeHeaderExtension" {
+			t.Errorf("DecodeBlock() = %v, want the DecodeError of the Extension", err)
+		}
+		decodedBlock, _ := (&Decoder{Lenient: true}).DecodeBlock(block)
+		channelHeader := decodedBlock.Data.Data[0].Payload.Header.ChannelHeader
+		if channelHeader.ChannelId != "mychannel" || len(channelHeader.Failures) != 1 {
+			t.Errorf("lenient ChannelHeader = %+v, want the ChannelHeader with the failure of the Extension", channelHeader)
+		}
+	})
+}